@@ -0,0 +1,292 @@
+package gdsp
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// AdaptiveAlgorithm values represent the algorithm used by an AdaptiveFilter to
+// update its weights.
+type AdaptiveAlgorithm int
+
+// Types of adaptive algorithms.
+const (
+	// AdaptiveAlgorithmLMS is the least mean squares algorithm.
+	AdaptiveAlgorithmLMS AdaptiveAlgorithm = iota + 1
+
+	// AdaptiveAlgorithmLeakyLMS is the least mean squares algorithm with weights
+	// that decay towards zero.
+	AdaptiveAlgorithmLeakyLMS
+
+	// AdaptiveAlgorithmNLMS is the normalized least mean squares algorithm.
+	AdaptiveAlgorithmNLMS
+
+	// AdaptiveAlgorithmSignErrorLMS is the least mean squares algorithm using the
+	// sign of the error.
+	AdaptiveAlgorithmSignErrorLMS
+
+	// AdaptiveAlgorithmRLS is the exponentially weighted recursive least squares
+	// algorithm.
+	AdaptiveAlgorithmRLS
+
+	// AdaptiveAlgorithmAffineProjection is the affine projection algorithm.
+	AdaptiveAlgorithmAffineProjection
+)
+
+// AdaptiveFilter types are FIR filters whose weights adapt, one sample at a time,
+// so that the filter's output tracks a desired signal. The output for input
+// x[n] is y[n] = sum(w[k] * x[n - k]) and the error is e[n] = d[n] - y[n], where
+// d[n] is the desired signal, so that the weights of a filter identifying an
+// unknown FIR system converge to its coefficients for both real-valued and
+// complex-valued signals.
+type AdaptiveFilter struct {
+	algorithm      AdaptiveAlgorithm
+	length         int
+	stepSize       float64
+	leakage        float64
+	regularization float64
+	forgetting     float64
+	order          int
+
+	weights VectorComplex
+	history VectorComplex
+	desired VectorComplex
+	p       MatrixComplex
+}
+
+// MakeLMS creates an LMS adaptive filter with the given number of weights and
+// step size, mu. The weights are updated with w += mu * e * conj(x). The step
+// size must be less than 2 / (length * input power) for the filter to converge.
+func MakeLMS(length int, stepSize float64) *AdaptiveFilter {
+	return makeAdaptiveFilter(AdaptiveAlgorithmLMS, length, &AdaptiveFilter{stepSize: stepSize})
+}
+
+// MakeLeakyLMS creates a leaky LMS adaptive filter with the given number of
+// weights, step size, mu, and leakage, gamma. The weights are updated with
+// w = (1 - mu * gamma) * w + mu * e * conj(x), which keeps them bounded when the
+// input does not excite every mode of the filter.
+func MakeLeakyLMS(length int, stepSize float64, leakage float64) *AdaptiveFilter {
+	return makeAdaptiveFilter(AdaptiveAlgorithmLeakyLMS, length, &AdaptiveFilter{stepSize: stepSize, leakage: leakage})
+}
+
+// MakeNLMS creates a normalized LMS adaptive filter with the given number of
+// weights, step size, mu, and regularization, epsilon. The weights are updated
+// with w += mu * e * conj(x) / (epsilon + |x|^2), which makes convergence
+// independent of the input power. The step size should be in the range (0, 2).
+func MakeNLMS(length int, stepSize float64, regularization float64) *AdaptiveFilter {
+	return makeAdaptiveFilter(AdaptiveAlgorithmNLMS, length, &AdaptiveFilter{stepSize: stepSize, regularization: regularization})
+}
+
+// MakeSignErrorLMS creates a sign-error LMS adaptive filter with the given number
+// of weights and step size, mu. The weights are updated with
+// w += mu * sign(e) * conj(x), where the sign of a complex error is taken
+// separately for its real and imaginary parts.
+func MakeSignErrorLMS(length int, stepSize float64) *AdaptiveFilter {
+	return makeAdaptiveFilter(AdaptiveAlgorithmSignErrorLMS, length, &AdaptiveFilter{stepSize: stepSize})
+}
+
+// MakeRLS creates a recursive least squares adaptive filter with the given number
+// of weights, forgetting factor, lambda, in the range (0, 1] and initial inverse
+// correlation matrix I / delta. Small values of delta give faster initial
+// convergence. nil is returned if lambda or delta is out of range.
+func MakeRLS(length int, forgetting float64, delta float64) *AdaptiveFilter {
+	if forgetting <= 0.0 || forgetting > 1.0 || delta <= 0.0 {
+		return nil
+	}
+	return makeAdaptiveFilter(AdaptiveAlgorithmRLS, length, &AdaptiveFilter{forgetting: forgetting, regularization: delta})
+}
+
+// MakeAffineProjection creates an affine projection adaptive filter with the
+// given number of weights, projection order, K, step size, mu, and
+// regularization, epsilon. Each update projects the weights on to the K most
+// recent input vectors, which speeds up convergence for colored inputs. With
+// K = 1 the filter is an NLMS filter.
+func MakeAffineProjection(length int, order int, stepSize float64, regularization float64) *AdaptiveFilter {
+	if order < 1 {
+		return nil
+	}
+	return makeAdaptiveFilter(AdaptiveAlgorithmAffineProjection, length, &AdaptiveFilter{order: order, stepSize: stepSize, regularization: regularization})
+}
+
+// makeAdaptiveFilter finishes creating the adaptive filter f.
+func makeAdaptiveFilter(algorithm AdaptiveAlgorithm, length int, f *AdaptiveFilter) *AdaptiveFilter {
+	if length < 1 {
+		return nil
+	}
+
+	f.algorithm = algorithm
+	f.length = length
+	if f.order < 1 {
+		f.order = 1
+	}
+
+	f.Reset()
+	return f
+}
+
+// Reset sets the filter's weights to zero and clears its input history.
+func (f *AdaptiveFilter) Reset() {
+	f.weights = MakeVectorComplex(0.0, f.length)
+	f.history = MakeVectorComplex(0.0, f.length+f.order-1)
+	f.desired = MakeVectorComplex(0.0, f.order)
+
+	if f.algorithm == AdaptiveAlgorithmRLS {
+		f.p = MakeMatrixComplex(0.0, f.length, f.length)
+		for i := range f.p {
+			f.p[i][i] = complex(1.0/f.regularization, 0.0)
+		}
+	}
+}
+
+// Algorithm returns the filter's adaptive algorithm.
+func (f *AdaptiveFilter) Algorithm() AdaptiveAlgorithm {
+	return f.algorithm
+}
+
+// Weights returns the real part of the filter's current weights.
+func (f *AdaptiveFilter) Weights() Vector {
+	return f.weights.Real()
+}
+
+// WeightsC returns the filter's current weights.
+func (f *AdaptiveFilter) WeightsC() VectorComplex {
+	return f.weights.Copy()
+}
+
+// Update filters the real-valued input sample x, adapts the weights towards the
+// desired sample d and returns the filter's output and error before the update.
+func (f *AdaptiveFilter) Update(x float64, d float64) (float64, float64) {
+	y, e := f.UpdateC(complex(x, 0.0), complex(d, 0.0))
+	return real(y), real(e)
+}
+
+// UpdateC filters the complex-valued input sample x, adapts the weights towards
+// the desired sample d and returns the filter's output and error before the
+// update.
+func (f *AdaptiveFilter) UpdateC(x complex128, d complex128) (complex128, complex128) {
+	copy(f.history[1:], f.history)
+	f.history[0] = x
+	copy(f.desired[1:], f.desired)
+	f.desired[0] = d
+
+	u := f.history[:f.length]
+	y := VMulESumC(f.weights, u)
+	e := d - y
+	mu := complex(f.stepSize, 0.0)
+
+	switch f.algorithm {
+	case AdaptiveAlgorithmLMS:
+		f.weights = VAddC(f.weights, VSMulC(u.Conj(), mu*e))
+	case AdaptiveAlgorithmLeakyLMS:
+		f.weights = VAddC(VSMulC(f.weights, complex(1.0-f.stepSize*f.leakage, 0.0)), VSMulC(u.Conj(), mu*e))
+	case AdaptiveAlgorithmNLMS:
+		norm := complex(f.regularization+VESum(VSqMagC(u)), 0.0)
+		f.weights = VAddC(f.weights, VSMulC(u.Conj(), mu*e/norm))
+	case AdaptiveAlgorithmSignErrorLMS:
+		sign := complex(signum(real(e)), signum(imag(e)))
+		f.weights = VAddC(f.weights, VSMulC(u.Conj(), mu*sign))
+	case AdaptiveAlgorithmRLS:
+		f.updateRLS(u, e)
+	case AdaptiveAlgorithmAffineProjection:
+		f.updateAffineProjection()
+	}
+	return y, e
+}
+
+// Process filters the real-valued input vector, adapting the weights towards the
+// desired vector d one sample at a time, and returns the filter's output and
+// error for each sample. nil is returned if the vectors have different lengths.
+func (f *AdaptiveFilter) Process(x Vector, d Vector) (Vector, Vector) {
+	y, e := f.ProcessC(x.ToComplex(), d.ToComplex())
+	if y == nil {
+		return nil, nil
+	}
+	return y.Real(), e.Real()
+}
+
+// ProcessC filters the complex-valued input vector, adapting the weights towards
+// the desired vector d one sample at a time, and returns the filter's output and
+// error for each sample. nil is returned if the vectors have different lengths.
+func (f *AdaptiveFilter) ProcessC(x VectorComplex, d VectorComplex) (VectorComplex, VectorComplex) {
+	if len(x) != len(d) {
+		return nil, nil
+	}
+
+	y := MakeVectorComplex(0.0, len(x))
+	e := MakeVectorComplex(0.0, len(x))
+	for i := range x {
+		y[i], e[i] = f.UpdateC(x[i], d[i])
+	}
+	return y, e
+}
+
+// updateRLS updates the weights and inverse correlation matrix with the input
+// vector u and error e. The recursion is written for the conjugate weights,
+// conj(w), so that the output is conj(w)^H u.
+func (f *AdaptiveFilter) updateRLS(u VectorComplex, e complex128) {
+	pu := MakeVectorComplex(0.0, f.length)
+	for i := range pu {
+		pu[i] = VMulESumC(f.p[i], u)
+	}
+
+	uhpu := VMulESumC(u.Conj(), pu)
+	k := VSDivC(pu, complex(f.forgetting, 0.0)+uhpu)
+
+	// u^H P is the conjugate transpose of P u, since P is Hermitian.
+	for i := range f.p {
+		for j := range f.p[i] {
+			f.p[i][j] = (f.p[i][j] - k[i]*cmplx.Conj(pu[j])) / complex(f.forgetting, 0.0)
+		}
+	}
+
+	// Keep P Hermitian, since round-off errors that break its symmetry grow and
+	// make the recursion diverge.
+	for i := range f.p {
+		f.p[i][i] = complex(real(f.p[i][i]), 0.0)
+		for j := i + 1; j < len(f.p); j++ {
+			v := (f.p[i][j] + cmplx.Conj(f.p[j][i])) / 2.0
+			f.p[i][j] = v
+			f.p[j][i] = cmplx.Conj(v)
+		}
+	}
+
+	f.weights = VAddC(f.weights, VSMulC(k.Conj(), e))
+}
+
+// updateAffineProjection updates the weights using the order most recent input
+// vectors and desired samples.
+func (f *AdaptiveFilter) updateAffineProjection() {
+	inputs := make(MatrixComplex, f.order)
+	e := MakeMatrixComplex(0.0, f.order, 1)
+	for j := range inputs {
+		inputs[j] = f.history[j : j+f.length]
+		e[j][0] = cmplx.Conj(f.desired[j] - VMulESumC(f.weights, inputs[j]))
+	}
+
+	// Solve (X^H X + epsilon I) g = conj(e), where the columns of X are the input
+	// vectors, and update conj(w) by mu X g.
+	gram := MakeMatrixComplex(0.0, f.order, f.order)
+	for i := range gram {
+		for j := range gram[i] {
+			gram[i][j] = VMulESumC(inputs[i].Conj(), inputs[j])
+		}
+		gram[i][i] += complex(f.regularization, 0.0)
+	}
+
+	g := SolveC(gram, e)
+	if g == nil {
+		return
+	}
+
+	for j, input := range inputs {
+		f.weights = VAddC(f.weights, VSMulC(input.Conj(), complex(f.stepSize, 0.0)*cmplx.Conj(g[j][0])))
+	}
+}
+
+// signum returns the sign of x, or zero if x is zero.
+func signum(x float64) float64 {
+	if x == 0.0 {
+		return 0.0
+	}
+	return math.Copysign(1.0, x)
+}
@@ -0,0 +1,88 @@
+package gdsp
+
+import (
+	"testing"
+)
+
+func adaptiveFilters() map[string]*AdaptiveFilter {
+	return map[string]*AdaptiveFilter{
+		"LMS":               MakeLMS(4, 0.5),
+		"LeakyLMS":          MakeLeakyLMS(4, 0.5, 0.00001),
+		"NLMS":              MakeNLMS(4, 0.5, 0.000001),
+		"SignErrorLMS":      MakeSignErrorLMS(4, 0.002),
+		"RLS":               MakeRLS(4, 0.99, 0.01),
+		"AffineProjection":  MakeAffineProjection(4, 3, 0.5, 0.000001),
+		"AffineProjection1": MakeAffineProjection(4, 1, 0.5, 0.000001),
+	}
+}
+
+func TestAdaptiveFilterIdentification(t *testing.T) {
+	h := Vector{0.5, -0.3, 0.2, 0.1}
+	x := noise(4000, 2.0)
+	d, _ := Filter(h, Vector{1.0}, x, nil)
+
+	for name, f := range adaptiveFilters() {
+		y, e := f.Process(x, d)
+		if len(y) != len(x) || len(e) != len(x) {
+			t.Fatalf("%s: output lengths should be %d.", name, len(x))
+		}
+
+		if w := f.Weights(); !w.IsCloseToVector(h, 0.01) {
+			t.Errorf("%s: weights %v should be %v.", name, w, h)
+		}
+
+		if !IsClose(y[len(y)-1]+e[len(e)-1], d[len(d)-1], 0.000001) {
+			t.Errorf("%s: output and error should sum to the desired signal.", name)
+		}
+	}
+}
+
+func TestAdaptiveFilterIdentificationC(t *testing.T) {
+	h := VectorComplex{0.5 + 0.2i, -0.3i, 0.2 - 0.1i}
+	x := MakeVectorComplexFromSplit(noise(4000, 3.0), noise(4000, 4.0))
+	d, _ := FilterC(h, VectorComplex{1.0}, x, nil)
+
+	filters := map[string]*AdaptiveFilter{
+		"LMS":              MakeLMS(3, 0.5),
+		"NLMS":             MakeNLMS(3, 0.5, 0.000001),
+		"SignErrorLMS":     MakeSignErrorLMS(3, 0.002),
+		"RLS":              MakeRLS(3, 0.99, 0.01),
+		"AffineProjection": MakeAffineProjection(3, 2, 0.5, 0.000001),
+	}
+
+	for name, f := range filters {
+		f.ProcessC(x, d)
+		if w := f.WeightsC(); !w.IsCloseToVectorC(h, 0.01) {
+			t.Errorf("%s: weights %v should be %v.", name, w, h)
+		}
+	}
+}
+
+func TestAdaptiveFilterUpdate(t *testing.T) {
+	h := Vector{1.0, 0.5}
+	x := noise(50, 6.0)
+	d, _ := Filter(h, Vector{1.0}, x, nil)
+
+	f := MakeNLMS(2, 0.8, 0.000001)
+	_, e := f.Process(x, d)
+
+	g := MakeNLMS(2, 0.8, 0.000001)
+	for i := range x {
+		if _, ei := g.Update(x[i], d[i]); !IsClose(ei, e[i], 0.000001) {
+			t.Errorf("Error %f at %d should be %f.", ei, i, e[i])
+		}
+	}
+
+	g.Reset()
+	if w := g.Weights(); !w.IsCloseToVector(Vector{0.0, 0.0}, 0.000001) {
+		t.Errorf("Reset weights %v should be zero.", w)
+	}
+
+	if y, _ := g.Process(x, d[:10]); y != nil {
+		t.Errorf("Mismatched lengths should return nil.")
+	}
+
+	if g.Algorithm() != AdaptiveAlgorithmNLMS {
+		t.Errorf("Algorithm %d should be NLMS.", g.Algorithm())
+	}
+}
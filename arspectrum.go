@@ -0,0 +1,65 @@
+package gdsp
+
+// ARSpectrum evaluates the power spectral density of the autoregressive model
+// with real-valued parameters a and noise variance, variance, at nfft frequencies.
+// The density, variance / |A(e^jw)|^2, is returned for the non-negative
+// normalized frequencies in cycles per sample with the power of the negative
+// frequencies folded in.
+func ARSpectrum(a Vector, variance float64, nfft int) (Vector, Vector) {
+	if nfft < len(a) {
+		nfft = len(a)
+	}
+
+	p := arDensity(a.ToComplex(), variance, nfft)
+	return welchFrequencies(nfft, 1.0, PSDSidesOne), welchOneSided(p.ToComplex(), nfft).Real()
+}
+
+// ARSpectrumC evaluates the power spectral density of the autoregressive model
+// with complex-valued parameters a and noise variance, variance, at nfft
+// frequencies. The density, variance / |A(e^jw)|^2, is returned for all
+// normalized frequencies in cycles per sample in FFT order.
+func ARSpectrumC(a VectorComplex, variance float64, nfft int) (Vector, Vector) {
+	if nfft < len(a) {
+		nfft = len(a)
+	}
+
+	return welchFrequencies(nfft, 1.0, PSDSidesTwo), arDensity(a, variance, nfft)
+}
+
+// PBurg estimates the power spectral density of x, sampled at fs, by fitting an
+// autoregressive model of order p with Burg's method. The function returns the
+// non-negative frequencies and the density at each frequency. nil is returned if
+// x has fewer than p + 1 samples.
+func PBurg(x Vector, p int, nfft int, fs float64) (Vector, Vector) {
+	a, variance := Arburg(x, p)
+	if a == nil {
+		return nil, nil
+	}
+
+	f, pxx := ARSpectrum(a, variance, nfft)
+	return VSMul(f, fs), VSDiv(pxx, fs)
+}
+
+// PYulear estimates the power spectral density of x, sampled at fs, by fitting
+// an autoregressive model of order p with the Yule-Walker method. The function
+// returns the non-negative frequencies and the density at each frequency. nil is
+// returned if x has fewer than p + 1 samples.
+func PYulear(x Vector, p int, nfft int, fs float64) (Vector, Vector) {
+	a, variance := Aryule(x, p)
+	if a == nil {
+		return nil, nil
+	}
+
+	f, pxx := ARSpectrum(a, variance, nfft)
+	return VSMul(f, fs), VSDiv(pxx, fs)
+}
+
+// arDensity returns variance / |A(e^jw)|^2 at nfft frequencies in FFT order.
+func arDensity(a VectorComplex, variance float64, nfft int) Vector {
+	A := VSqMagC(FFT(a.PaddedTrailing(0.0, nfft-len(a))))
+	p := MakeVector(0.0, nfft)
+	for i := range p {
+		p[i] = variance / A[i]
+	}
+	return p
+}
@@ -0,0 +1,87 @@
+package gdsp
+
+import (
+	"math"
+	"testing"
+)
+
+func TestARSpectrum(t *testing.T) {
+	a := MakeVectorFromArray([]float64{1.0, -0.5})
+	f, p := ARSpectrum(a, 1.0, 256)
+
+	if len(f) != 129 || f[128] != 0.5 {
+		t.Fatalf("Frequencies should span [0, 0.5] in 129 bins (%d).", len(f))
+	}
+
+	if !IsClose(p[0], 4.0, 0.000001) {
+		t.Errorf("%f should be 4.0.", p[0])
+	}
+
+	power := VESum(p) / 256.0
+	if !IsClose(power, 1.0/0.75, 0.000001) {
+		t.Errorf("Total power %f should be %f.", power, 1.0/0.75)
+	}
+}
+
+func TestARSpectrumC(t *testing.T) {
+	a := MakeVectorComplexFromArray([]complex128{1.0, complex(0.0, -0.5)})
+	f, p := ARSpectrumC(a, 2.0, 64)
+
+	peak := 0
+	for i := range p {
+		if p[i] > p[peak] {
+			peak = i
+		}
+	}
+
+	if f[peak] != 0.25 {
+		t.Errorf("Peak frequency %f should be 0.25.", f[peak])
+	}
+}
+
+func TestPYulear(t *testing.T) {
+	x := MakeVector(0.0, 4096)
+	e := noise(len(x), 3.0)
+	for i := range x {
+		x[i] = e[i]
+		if i > 0 {
+			x[i] += 0.9 * x[i-1]
+		}
+	}
+
+	a, _ := Aryule(x, 1)
+	if math.Abs(a[1]+0.9) > 0.05 {
+		t.Errorf("%f should be -0.9.", a[1])
+	}
+
+	f, p := PYulear(x, 1, 256, 100.0)
+	if f[128] != 50.0 || p[0] < p[128] {
+		t.Error("The spectrum of a lowpass AR process should decrease with frequency.")
+	}
+}
+
+func TestARShortInput(t *testing.T) {
+	if a, v := Aryule(Vector{1.0, 2.0}, 2); a != nil || v != 0.0 {
+		t.Error("Aryule should return nil with fewer than p + 1 samples.")
+	}
+
+	if a, v := AryuleC(VectorComplex{1.0, 2.0}, 2); a != nil || v != 0.0 {
+		t.Error("AryuleC should return nil with fewer than p + 1 samples.")
+	}
+
+	if f, p := PYulear(Vector{1.0, 2.0}, 2, 16, 1.0); f != nil || p != nil {
+		t.Error("PYulear should return nil with fewer than p + 1 samples.")
+	}
+
+	if a, v := Arburg(Vector{1.0, 2.0}, 4); a != nil || v != 0.0 {
+		t.Error("Arburg should return nil with fewer than p + 1 samples.")
+	}
+
+	if a, v := ArburgC(VectorComplex{1.0, 2.0}, 4); a != nil || v != 0.0 {
+		t.Error("ArburgC should return nil with fewer than p + 1 samples.")
+	}
+
+	if f, p := PBurg(Vector{1.0, 2.0}, 4, 16, 1.0); f != nil || p != nil {
+		t.Error("PBurg should return nil with fewer than p + 1 samples.")
+	}
+}
@@ -2,16 +2,16 @@
 package gdsp
 
 import (
-	"fmt"
 	"math/cmplx"
 )
 
 // Arburg finds the autoregressive parameters for a model with order p using Burg's
 // method on the real-valued input vector, x, and returns the parameters along with
-// the estimated variance.
+// the estimated variance. nil and 0 are returned if x has fewer than p + 1
+// samples.
 func Arburg(x Vector, p int) (Vector, float64) {
 	if len(x) < p+1 {
-		fmt.Printf("Vector length %d should be greater than p, %d.", len(x), p)
+		return nil, 0.0
 	}
 
 	N := len(x)
@@ -46,10 +46,11 @@ func Arburg(x Vector, p int) (Vector, float64) {
 
 // ArburgC finds the autoregressive parameters for a model with order p using Burg's
 // method on the complex-valued input vector, x, and returns the parameters along
-// with the estimated variance.
+// with the estimated variance. nil and 0 are returned if x has fewer than p + 1
+// samples.
 func ArburgC(x VectorComplex, p int) (VectorComplex, complex128) {
 	if len(x) < p+1 {
-		fmt.Printf("Vector length %d should be greater than p, %d.", len(x), p)
+		return nil, 0.0
 	}
 
 	N := len(x)
@@ -85,3 +86,70 @@ func ArburgC(x VectorComplex, p int) (VectorComplex, complex128) {
 
 	return a, E
 }
+
+// Aryule finds the autoregressive parameters for a model with order p using the
+// Yule-Walker method on the real-valued input vector, x, and returns the
+// parameters along with the estimated variance. The Yule-Walker equations are
+// solved with the Levinson-Durbin recursion on the biased autocorrelation of x.
+// nil and 0 are returned if x has fewer than p + 1 samples.
+func Aryule(x Vector, p int) (Vector, float64) {
+	if len(x) < p+1 {
+		return nil, 0.0
+	}
+
+	r := VSDiv(ACorr(x), float64(len(x)))
+	a := MakeVector(0.0, p+1)
+	a[0] = 1.0
+	E := r[0]
+
+	for m := 1; m <= p; m++ {
+		acc := r[m]
+		for i := 1; i < m; i++ {
+			acc += a[i] * r[m-i]
+		}
+		k := -acc / E
+
+		revA := a.Reverse(m-1, 0)
+		for i := 1; i < m; i++ {
+			a[i] += k * revA[i-1]
+		}
+		a[m] = k
+
+		E = (1.0 - k*k) * E
+	}
+
+	return a, E
+}
+
+// AryuleC finds the autoregressive parameters for a model with order p using the
+// Yule-Walker method on the complex-valued input vector, x, and returns the
+// parameters along with the estimated variance. nil and 0 are returned if x has
+// fewer than p + 1 samples.
+func AryuleC(x VectorComplex, p int) (VectorComplex, float64) {
+	if len(x) < p+1 {
+		return nil, 0.0
+	}
+
+	r := VSDivC(ACorrC(x), ComplexRI(len(x)))
+	a := MakeVectorComplex(0.0, p+1)
+	a[0] = 1.0
+	E := real(r[0])
+
+	for m := 1; m <= p; m++ {
+		acc := r[m]
+		for i := 1; i < m; i++ {
+			acc += a[i] * r[m-i]
+		}
+		k := -acc / complex(E, 0.0)
+
+		revA := a.Reverse(m-1, 0).Conj()
+		for i := 1; i < m; i++ {
+			a[i] += k * revA[i-1]
+		}
+		a[m] = k
+
+		E = (1.0 - real(cmplx.Conj(k)*k)) * E
+	}
+
+	return a, E
+}
@@ -0,0 +1,71 @@
+package gdsp
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Angle returns the phase angle, in radians, of each element of v.
+func Angle(v VectorComplex) Vector {
+	a := MakeVector(0.0, len(v))
+	for i, c := range v {
+		a[i] = cmplx.Phase(c)
+	}
+	return a
+}
+
+// Unwrap unwraps the radian phase vector by adding multiples of 2 pi wherever
+// the difference between consecutive elements is greater than tolerance.
+// Tolerances less than pi are treated as pi.
+func Unwrap(phase Vector, tolerance float64) Vector {
+	tolerance = math.Max(tolerance, math.Pi)
+
+	u := phase.Copy()
+	offset := 0.0
+	for i := 1; i < len(phase); i++ {
+		d := phase[i] - phase[i-1]
+		if d > tolerance {
+			offset -= 2.0 * math.Pi * math.Ceil((d-tolerance)/(2.0*math.Pi))
+		} else if d < -tolerance {
+			offset += 2.0 * math.Pi * math.Ceil((-d-tolerance)/(2.0*math.Pi))
+		}
+		u[i] = phase[i] + offset
+	}
+	return u
+}
+
+// Mag2dB converts each magnitude in v to decibels, 20 log10(v[i]).
+func Mag2dB(v Vector) Vector {
+	db := MakeVector(0.0, len(v))
+	for i, r := range v {
+		db[i] = 20.0 * math.Log10(r)
+	}
+	return db
+}
+
+// DB2Mag converts each decibel value in v to a magnitude, 10^(v[i] / 20).
+func DB2Mag(v Vector) Vector {
+	m := MakeVector(0.0, len(v))
+	for i, r := range v {
+		m[i] = math.Pow(10.0, r/20.0)
+	}
+	return m
+}
+
+// Pow2dB converts each power in v to decibels, 10 log10(v[i]).
+func Pow2dB(v Vector) Vector {
+	db := MakeVector(0.0, len(v))
+	for i, r := range v {
+		db[i] = 10.0 * math.Log10(r)
+	}
+	return db
+}
+
+// DB2Pow converts each decibel value in v to a power, 10^(v[i] / 10).
+func DB2Pow(v Vector) Vector {
+	p := MakeVector(0.0, len(v))
+	for i, r := range v {
+		p[i] = math.Pow(10.0, r/10.0)
+	}
+	return p
+}
@@ -0,0 +1,105 @@
+package gdsp
+
+// TransferEstimator values represent an estimator of a transfer function.
+type TransferEstimator int
+
+// Types of transfer function estimators.
+const (
+	// TransferEstimatorH1 divides the cross-spectral density by the input power
+	// spectral density, which is unbiased by noise on the output.
+	TransferEstimatorH1 TransferEstimator = iota + 1
+
+	// TransferEstimatorH2 divides the output power spectral density by the
+	// cross-spectral density, which is unbiased by noise on the input.
+	TransferEstimatorH2
+)
+
+// CSD estimates the cross-spectral density of x and y, sampled at fs, using
+// Welch's method. The arguments have the same meaning as they do for Welch. The
+// estimate is conj(X) * Y averaged over segments, so CSD(x, x, ...) is the power
+// spectral density of x.
+//
+// The function returns the frequencies and the cross-spectral density estimate
+// at each frequency. If x and y have different lengths, nil is returned.
+func CSD(x Vector, y Vector, fs float64, windowType WindowType, segmentLength int, overlap int, nfft int, detrend DetrendType, scaling PSDScaling, sides PSDSides, average PSDAverage) (Vector, VectorComplex) {
+	if len(x) != len(y) {
+		return nil, nil
+	}
+
+	segmentLength, nfft = welchLengths(len(x), segmentLength, nfft)
+	if segmentLength < 1 || overlap < 0 || overlap >= segmentLength {
+		return nil, nil
+	}
+
+	w := MakeWindow(windowType, segmentLength)
+	if w == nil {
+		return nil, nil
+	}
+
+	sx := welchSpectra(x.ToComplex(), w, segmentLength, overlap, nfft, detrend)
+	sy := welchSpectra(y.ToComplex(), w, segmentLength, overlap, nfft, detrend)
+	periodograms := make([]VectorComplex, len(sx))
+	for i := range sx {
+		periodograms[i] = VSMulC(VMulEC(sx[i].Conj(), sy[i]), complex(welchScale(w, fs, scaling), 0.0))
+	}
+
+	p := welchAverageC(periodograms, average)
+	if sides == PSDSidesOne {
+		p = welchOneSided(p, nfft)
+	}
+
+	return welchFrequencies(nfft, fs, sides), p
+}
+
+// Coherence estimates the magnitude-squared coherence of x and y, sampled at fs,
+// using Welch's method with mean averaging. The coherence is
+// |Pxy|^2 / (Pxx * Pyy) and lies in the range [0, 1].
+//
+// The function returns the non-negative frequencies and the coherence at each
+// frequency.
+func Coherence(x Vector, y Vector, fs float64, windowType WindowType, segmentLength int, overlap int, nfft int, detrend DetrendType) (Vector, Vector) {
+	f, pxy := CSD(x, y, fs, windowType, segmentLength, overlap, nfft, detrend, PSDScalingDensity, PSDSidesOne, PSDAverageMean)
+	if pxy == nil {
+		return nil, nil
+	}
+
+	_, pxx := Welch(x, fs, windowType, segmentLength, overlap, nfft, detrend, PSDScalingDensity, PSDSidesOne, PSDAverageMean)
+	_, pyy := Welch(y, fs, windowType, segmentLength, overlap, nfft, detrend, PSDScalingDensity, PSDSidesOne, PSDAverageMean)
+
+	c := VSqMagC(pxy)
+	for i := range c {
+		c[i] /= pxx[i] * pyy[i]
+	}
+	return f, c
+}
+
+// TransferFunction estimates the transfer function from the input x to the
+// output y, sampled at fs, using Welch's method with mean averaging and the given
+// estimator.
+//
+// The function returns the non-negative frequencies and the complex-valued
+// frequency response at each frequency.
+func TransferFunction(x Vector, y Vector, fs float64, windowType WindowType, segmentLength int, overlap int, nfft int, detrend DetrendType, estimator TransferEstimator) (Vector, VectorComplex) {
+	f, pxy := CSD(x, y, fs, windowType, segmentLength, overlap, nfft, detrend, PSDScalingDensity, PSDSidesOne, PSDAverageMean)
+	if pxy == nil {
+		return nil, nil
+	}
+
+	h := MakeVectorComplex(0.0, len(pxy))
+	switch estimator {
+	case TransferEstimatorH1:
+		_, pxx := Welch(x, fs, windowType, segmentLength, overlap, nfft, detrend, PSDScalingDensity, PSDSidesOne, PSDAverageMean)
+		for i := range h {
+			h[i] = pxy[i] / complex(pxx[i], 0.0)
+		}
+	case TransferEstimatorH2:
+		_, pyy := Welch(y, fs, windowType, segmentLength, overlap, nfft, detrend, PSDScalingDensity, PSDSidesOne, PSDAverageMean)
+		pyx := pxy.Conj()
+		for i := range h {
+			h[i] = complex(pyy[i], 0.0) / pyx[i]
+		}
+	default:
+		return nil, nil
+	}
+	return f, h
+}
@@ -0,0 +1,43 @@
+package gdsp
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestCSDMatchesWelch(t *testing.T) {
+	x := noise(512, 1.0)
+	_, pxx := Welch(x, 1.0, WindowTypeHann, 64, 32, 64, DetrendTypeConstant, PSDScalingDensity, PSDSidesOne, PSDAverageMean)
+	_, pxy := CSD(x, x, 1.0, WindowTypeHann, 64, 32, 64, DetrendTypeConstant, PSDScalingDensity, PSDSidesOne, PSDAverageMean)
+
+	if !pxy.IsCloseToVectorC(pxx.ToComplex(), 0.000001) {
+		t.Error("The cross-spectral density of x with itself should be its power spectral density.")
+	}
+}
+
+func TestTransferFunction(t *testing.T) {
+	b := MakeVectorFromArray([]float64{0.5, 0.3})
+	a := MakeVectorFromArray([]float64{1.0, -0.2})
+	x := noise(2048, 2.0)
+	y, _ := Filter(b, a, x, nil)
+
+	estimators := []TransferEstimator{TransferEstimatorH1, TransferEstimatorH2}
+	for _, estimator := range estimators {
+		f, h := TransferFunction(x, y, 1.0, WindowTypeHann, 128, 64, 128, DetrendTypeNone, estimator)
+		for i := range f {
+			z := cmplx.Exp(complex(0.0, -2.0*math.Pi*f[i]))
+			expected := (0.5 + 0.3*z) / (1.0 - 0.2*z)
+			if cmplx.Abs(h[i]-expected) > 0.05 {
+				t.Errorf("Estimator %d: %v at %f should be %v.", estimator, h[i], f[i], expected)
+			}
+		}
+	}
+
+	_, c := Coherence(x, y, 1.0, WindowTypeHann, 128, 64, 128, DetrendTypeNone)
+	for i := range c {
+		if c[i] < 0.95 || c[i] > 1.000001 {
+			t.Errorf("Coherence %f at %d should be close to 1.", c[i], i)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package gdsp
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// CZT performs a chirp Z-transform on the complex-valued input vector and returns
+// the result. The transform is evaluated at the m points z[k] = a * w^(-k) of a
+// spiral contour in the z-plane that starts at a and has ratio w between points,
+//
+// X[k] = sum(input[n] * z[k]^(-n))
+//
+// With a = 1 and w = exp(-2 * pi * i / m) the transform is the DFT. The
+// transform is computed with Bluestein's algorithm, which expresses it as a
+// convolution evaluated with power of two length FFTs.
+func CZT(input VectorComplex, m int, w complex128, a complex128) VectorComplex {
+	n := len(input)
+	if n == 0 || m < 1 {
+		return MakeVectorComplex(0.0, m)
+	}
+
+	l := 1
+	for l < n+m-1 {
+		l <<= 1
+	}
+
+	logW := cmplx.Log(w)
+	chirp := func(k int) complex128 {
+		return cmplx.Exp(logW * complex(float64(k)*float64(k)/2.0, 0.0))
+	}
+
+	y := MakeVectorComplex(0.0, l)
+	an := complex(1.0, 0.0)
+	ai := 1.0 / a
+	for i := 0; i < n; i++ {
+		y[i] = input[i] * an * chirp(i)
+		an *= ai
+	}
+
+	v := MakeVectorComplex(0.0, l)
+	for k := 0; k < m; k++ {
+		v[k] = 1.0 / chirp(k)
+	}
+	for k := 1; k < n; k++ {
+		v[l-k] = 1.0 / chirp(k)
+	}
+
+	g := IFFT(VMulEC(FFT(y), FFT(v)))
+
+	output := MakeVectorComplex(0.0, m)
+	for k := 0; k < m; k++ {
+		output[k] = g[k] * chirp(k)
+	}
+	return output
+}
+
+// FFTBluestein performs a discrete Fourier transform on the complex-valued input
+// vector using Bluestein's algorithm. Unlike FFT, it runs in O(N log N) time for
+// any length, including prime lengths.
+func FFTBluestein(input VectorComplex) VectorComplex {
+	n := len(input)
+	return CZT(input, n, cmplx.Exp(complex(0.0, -2.0*math.Pi/float64(n))), 1.0)
+}
+
+// ZoomFFT evaluates the discrete-time Fourier transform of the real-valued input
+// vector, sampled at fs, at m equally spaced frequencies from f1 to f2 inclusive
+// using a chirp Z-transform. The function returns the frequencies and the
+// transform at each frequency.
+func ZoomFFT(input Vector, f1 float64, f2 float64, m int, fs float64) (Vector, VectorComplex) {
+	return ZoomFFTC(input.ToComplex(), f1, f2, m, fs)
+}
+
+// ZoomFFTC evaluates the discrete-time Fourier transform of the complex-valued
+// input vector, sampled at fs, at m equally spaced frequencies from f1 to f2
+// inclusive using a chirp Z-transform. The function returns the frequencies and
+// the transform at each frequency.
+func ZoomFFTC(input VectorComplex, f1 float64, f2 float64, m int, fs float64) (Vector, VectorComplex) {
+	if m < 1 {
+		return nil, nil
+	}
+
+	step := 0.0
+	if m > 1 {
+		step = (f2 - f1) / float64(m-1)
+	}
+
+	f := MakeVector(0.0, m)
+	for k := range f {
+		f[k] = f1 + float64(k)*step
+	}
+
+	w := cmplx.Exp(complex(0.0, -2.0*math.Pi*step/fs))
+	a := cmplx.Exp(complex(0.0, 2.0*math.Pi*f1/fs))
+	return f, CZT(input, m, w, a)
+}
@@ -0,0 +1,76 @@
+package gdsp
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestCZT(t *testing.T) {
+	v := MakeVectorComplexFromArray([]complex128{1.0, 2.0, complex(3.0, 1.0), 4.0, 4.0, 3.0, 2.0, complex(1.0, -2.0)})
+	czt := CZT(v, 8, cmplx.Exp(complex(0.0, -2.0*math.Pi/8.0)), 1.0)
+
+	if !czt.IsCloseToVectorC(FFT(v), 0.000001) {
+		t.Errorf("%v should be %v.", czt, FFT(v))
+	}
+}
+
+func TestCZTSpiral(t *testing.T) {
+	v := MakeVectorComplexFromArray([]complex128{1.0, -2.0, complex(0.5, 1.0)})
+	w := cmplx.Rect(1.1, -0.4)
+	a := cmplx.Rect(0.9, 0.2)
+	czt := CZT(v, 5, w, a)
+
+	for k := range czt {
+		z := a * cmplx.Pow(w, complex(-float64(k), 0.0))
+		x := complex(0.0, 0.0)
+		for n := range v {
+			x += v[n] * cmplx.Pow(z, complex(-float64(n), 0.0))
+		}
+
+		if !IsCloseC(czt[k], x, 0.000001) {
+			t.Errorf("%v at %d should be %v.", czt[k], k, x)
+		}
+	}
+}
+
+func TestFFTBluestein(t *testing.T) {
+	v := MakeVectorComplex(0.0, 37)
+	for i := range v {
+		v[i] = complex(math.Sin(float64(i)), math.Cos(float64(i*i)))
+	}
+
+	if !FFTBluestein(v).IsCloseToVectorC(DFT(v, true), 0.000001) {
+		t.Error("Bluestein FFT should match the DFT.")
+	}
+}
+
+func TestZoomFFT(t *testing.T) {
+	fs := 1000.0
+	x := MakeVector(0.0, 1000)
+	for i := range x {
+		x[i] = math.Sin(2.0 * math.Pi * 50.3 * float64(i) / fs)
+	}
+
+	f, X := ZoomFFT(x, 49.0, 51.0, 201, fs)
+	if !IsClose(f[200], 51.0, 0.000001) {
+		t.Errorf("Last frequency %f should be 51.0.", f[200])
+	}
+
+	bins := VSMul(f, float64(len(x))/fs)
+	if !X.IsCloseToVectorC(Goertzel(x, bins), 0.000001) {
+		t.Error("Zoom FFT should match the Goertzel algorithm.")
+	}
+
+	peak := 0
+	mag := VMagC(X)
+	for i := range mag {
+		if mag[i] > mag[peak] {
+			peak = i
+		}
+	}
+
+	if !IsClose(f[peak], 50.3, 0.000001) {
+		t.Errorf("Peak frequency %f should be 50.3.", f[peak])
+	}
+}
@@ -0,0 +1,256 @@
+package gdsp
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// TransformType values represent the type of a discrete cosine or sine
+// transform.
+type TransformType int
+
+// Types of discrete cosine and sine transforms.
+const (
+	TransformTypeI TransformType = iota + 1
+	TransformTypeII
+	TransformTypeIII
+	TransformTypeIV
+)
+
+// DCT performs a discrete cosine transform of the given type on the real-valued
+// input vector and returns the result. The unnormalized transforms of a vector x
+// of length N are
+//
+// I:   y[k] = x[0] + (-1)^k x[N-1] + 2 sum(x[n] cos(pi k n / (N - 1))), 0 < n < N-1
+// II:  y[k] = 2 sum(x[n] cos(pi k (2n + 1) / (2N)))
+// III: y[k] = x[0] + 2 sum(x[n] cos(pi n (2k + 1) / (2N))), 0 < n
+// IV:  y[k] = 2 sum(x[n] cos(pi (2k + 1) (2n + 1) / (4N)))
+//
+// If orthonormal is true, the transform is scaled so that its matrix is
+// orthogonal. The transforms are computed with an FFT of length 2N, or 2(N - 1)
+// for type I, which requires N > 1. An empty input returns an empty vector.
+func DCT(input Vector, transformType TransformType, orthonormal bool) Vector {
+	N := len(input)
+	x := input.Copy()
+	if N == 0 {
+		return x
+	}
+
+	switch transformType {
+	case TransformTypeI:
+		if N < 2 {
+			return nil
+		}
+
+		if orthonormal {
+			x[0] *= math.Sqrt2
+			x[N-1] *= math.Sqrt2
+		}
+
+		Z := FFT(x.ToComplex().PaddedTrailing(0.0, N-2))
+		y := MakeVector(0.0, N)
+		for k := range y {
+			y[k] = 2.0*real(Z[k]) - x[0] - alternatingSign(k)*x[N-1]
+		}
+
+		if orthonormal {
+			y = VSDiv(y, math.Sqrt(2.0*float64(N-1)))
+			y[0] /= math.Sqrt2
+			y[N-1] /= math.Sqrt2
+		}
+		return y
+	case TransformTypeII:
+		Z := FFT(x.ToComplex().PaddedTrailing(0.0, N))
+		y := MakeVector(0.0, N)
+		for k := range y {
+			y[k] = 2.0 * real(halfBinShift(k, N)*Z[k])
+		}
+
+		if orthonormal {
+			y = VSDiv(y, math.Sqrt(2.0*float64(N)))
+			y[0] /= math.Sqrt2
+		}
+		return y
+	case TransformTypeIII:
+		if orthonormal {
+			x[0] *= math.Sqrt2
+		}
+
+		Z := FFT(modulate(x, N))
+		y := MakeVector(0.0, N)
+		for k := range y {
+			y[k] = 2.0*real(Z[k]) - x[0]
+		}
+
+		if orthonormal {
+			y = VSDiv(y, math.Sqrt(2.0*float64(N)))
+		}
+		return y
+	case TransformTypeIV:
+		Z := FFT(modulate(x, N))
+		y := MakeVector(0.0, N)
+		for k := range y {
+			y[k] = 2.0 * real(quarterBinShift(k, N)*Z[k])
+		}
+
+		if orthonormal {
+			y = VSDiv(y, math.Sqrt(2.0*float64(N)))
+		}
+		return y
+	}
+	return nil
+}
+
+// IDCT performs the inverse of the discrete cosine transform of the given type
+// on the real-valued input vector and returns the result. orthonormal should
+// match the scaling used for the forward transform.
+func IDCT(input Vector, transformType TransformType, orthonormal bool) Vector {
+	N := len(input)
+	y := DCT(input, inverseTransformType(transformType), orthonormal)
+	if orthonormal || y == nil {
+		return y
+	}
+
+	if transformType == TransformTypeI {
+		return VSDiv(y, 2.0*float64(N-1))
+	}
+	return VSDiv(y, 2.0*float64(N))
+}
+
+// DST performs a discrete sine transform of the given type on the real-valued
+// input vector and returns the result. The unnormalized transforms of a vector x
+// of length N are
+//
+// I:   y[k] = 2 sum(x[n] sin(pi (k + 1) (n + 1) / (N + 1)))
+// II:  y[k] = 2 sum(x[n] sin(pi (k + 1) (2n + 1) / (2N)))
+// III: y[k] = (-1)^k x[N-1] + 2 sum(x[n] sin(pi (2k + 1) (n + 1) / (2N))), n < N-1
+// IV:  y[k] = 2 sum(x[n] sin(pi (2k + 1) (2n + 1) / (4N)))
+//
+// If orthonormal is true, the transform is scaled so that its matrix is
+// orthogonal. The transforms are computed with an FFT of length 2N, or 2(N + 1)
+// for type I.
+func DST(input Vector, transformType TransformType, orthonormal bool) Vector {
+	N := len(input)
+	x := input.Copy()
+	if N == 0 {
+		return x
+	}
+
+	switch transformType {
+	case TransformTypeI:
+		z := MakeVectorComplex(0.0, 2*(N+1))
+		for n := range x {
+			z[n+1] = complex(x[n], 0.0)
+		}
+
+		Z := FFT(z)
+		y := MakeVector(0.0, N)
+		for k := range y {
+			y[k] = -2.0 * imag(Z[k+1])
+		}
+
+		if orthonormal {
+			y = VSDiv(y, math.Sqrt(2.0*float64(N+1)))
+		}
+		return y
+	case TransformTypeII:
+		Z := FFT(x.ToComplex().PaddedTrailing(0.0, N))
+		y := MakeVector(0.0, N)
+		for k := range y {
+			y[k] = -2.0 * imag(halfBinShift(k+1, N)*Z[k+1])
+		}
+
+		if orthonormal {
+			y = VSDiv(y, math.Sqrt(2.0*float64(N)))
+			y[N-1] /= math.Sqrt2
+		}
+		return y
+	case TransformTypeIII:
+		if orthonormal {
+			x[N-1] *= math.Sqrt2
+		}
+
+		z := MakeVectorComplex(0.0, 2*N)
+		for n := range x {
+			z[n+1] = complex(x[n], 0.0) * halfBinShift(n+1, N)
+		}
+
+		Z := FFT(z)
+		y := MakeVector(0.0, N)
+		for k := range y {
+			y[k] = -2.0*imag(Z[k]) - alternatingSign(k)*x[N-1]
+		}
+
+		if orthonormal {
+			y = VSDiv(y, math.Sqrt(2.0*float64(N)))
+		}
+		return y
+	case TransformTypeIV:
+		Z := FFT(modulate(x, N))
+		y := MakeVector(0.0, N)
+		for k := range y {
+			y[k] = -2.0 * imag(quarterBinShift(k, N)*Z[k])
+		}
+
+		if orthonormal {
+			y = VSDiv(y, math.Sqrt(2.0*float64(N)))
+		}
+		return y
+	}
+	return nil
+}
+
+// IDST performs the inverse of the discrete sine transform of the given type on
+// the real-valued input vector and returns the result. orthonormal should match
+// the scaling used for the forward transform.
+func IDST(input Vector, transformType TransformType, orthonormal bool) Vector {
+	N := len(input)
+	y := DST(input, inverseTransformType(transformType), orthonormal)
+	if orthonormal || y == nil {
+		return y
+	}
+
+	if transformType == TransformTypeI {
+		return VSDiv(y, 2.0*float64(N+1))
+	}
+	return VSDiv(y, 2.0*float64(N))
+}
+
+// inverseTransformType returns the transform type whose unnormalized transform is
+// proportional to the inverse of the given type.
+func inverseTransformType(transformType TransformType) TransformType {
+	switch transformType {
+	case TransformTypeII:
+		return TransformTypeIII
+	case TransformTypeIII:
+		return TransformTypeII
+	}
+	return transformType
+}
+
+// modulate returns x[n] * exp(-i pi n / (2N)) zero-padded to length 2N.
+func modulate(x Vector, N int) VectorComplex {
+	z := MakeVectorComplex(0.0, 2*N)
+	for n := range x {
+		z[n] = complex(x[n], 0.0) * halfBinShift(n, N)
+	}
+	return z
+}
+
+// halfBinShift returns exp(-i pi k / (2N)).
+func halfBinShift(k int, N int) complex128 {
+	return cmplx.Exp(complex(0.0, -math.Pi*float64(k)/(2.0*float64(N))))
+}
+
+// quarterBinShift returns exp(-i pi (2k + 1) / (4N)).
+func quarterBinShift(k int, N int) complex128 {
+	return cmplx.Exp(complex(0.0, -math.Pi*float64(2*k+1)/(4.0*float64(N))))
+}
+
+// alternatingSign returns (-1)^k.
+func alternatingSign(k int) float64 {
+	if k%2 == 0 {
+		return 1.0
+	}
+	return -1.0
+}
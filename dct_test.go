@@ -0,0 +1,124 @@
+package gdsp
+
+import (
+	"math"
+	"testing"
+)
+
+func naiveDCT(x Vector, transformType TransformType) Vector {
+	N := len(x)
+	y := MakeVector(0.0, N)
+	for k := 0; k < N; k++ {
+		for n := 0; n < N; n++ {
+			fk := float64(k)
+			fn := float64(n)
+			switch transformType {
+			case TransformTypeI:
+				if n == 0 || n == N-1 {
+					y[k] += x[n] * math.Cos(math.Pi*fk*fn/float64(N-1))
+				} else {
+					y[k] += 2.0 * x[n] * math.Cos(math.Pi*fk*fn/float64(N-1))
+				}
+			case TransformTypeII:
+				y[k] += 2.0 * x[n] * math.Cos(math.Pi*fk*(2.0*fn+1.0)/float64(2*N))
+			case TransformTypeIII:
+				if n == 0 {
+					y[k] += x[n]
+				} else {
+					y[k] += 2.0 * x[n] * math.Cos(math.Pi*fn*(2.0*fk+1.0)/float64(2*N))
+				}
+			case TransformTypeIV:
+				y[k] += 2.0 * x[n] * math.Cos(math.Pi*(2.0*fk+1.0)*(2.0*fn+1.0)/float64(4*N))
+			}
+		}
+	}
+	return y
+}
+
+func naiveDST(x Vector, transformType TransformType) Vector {
+	N := len(x)
+	y := MakeVector(0.0, N)
+	for k := 0; k < N; k++ {
+		for n := 0; n < N; n++ {
+			fk := float64(k)
+			fn := float64(n)
+			switch transformType {
+			case TransformTypeI:
+				y[k] += 2.0 * x[n] * math.Sin(math.Pi*(fk+1.0)*(fn+1.0)/float64(N+1))
+			case TransformTypeII:
+				y[k] += 2.0 * x[n] * math.Sin(math.Pi*(fk+1.0)*(2.0*fn+1.0)/float64(2*N))
+			case TransformTypeIII:
+				if n == N-1 {
+					y[k] += alternatingSign(k) * x[n]
+				} else {
+					y[k] += 2.0 * x[n] * math.Sin(math.Pi*(2.0*fk+1.0)*(fn+1.0)/float64(2*N))
+				}
+			case TransformTypeIV:
+				y[k] += 2.0 * x[n] * math.Sin(math.Pi*(2.0*fk+1.0)*(2.0*fn+1.0)/float64(4*N))
+			}
+		}
+	}
+	return y
+}
+
+var transformTypes = []TransformType{TransformTypeI, TransformTypeII, TransformTypeIII, TransformTypeIV}
+
+func TestDCTDST(t *testing.T) {
+	for _, N := range []int{2, 7, 8} {
+		x := MakeVector(0.0, N)
+		for i := range x {
+			x[i] = math.Sin(float64(i*i)) + 0.5
+		}
+
+		for _, transformType := range transformTypes {
+			if y := DCT(x, transformType, false); !y.IsCloseToVector(naiveDCT(x, transformType), 0.000001) {
+				t.Errorf("DCT type %d of length %d: %v should be %v.", transformType, N, y, naiveDCT(x, transformType))
+			}
+
+			if y := DST(x, transformType, false); !y.IsCloseToVector(naiveDST(x, transformType), 0.000001) {
+				t.Errorf("DST type %d of length %d: %v should be %v.", transformType, N, y, naiveDST(x, transformType))
+			}
+		}
+	}
+}
+
+func TestDCTDSTInverse(t *testing.T) {
+	x := MakeVectorFromArray([]float64{1.0, -2.0, 0.5, 3.0, 2.0, -1.0})
+
+	for _, orthonormal := range []bool{false, true} {
+		for _, transformType := range transformTypes {
+			dct := DCT(x, transformType, orthonormal)
+			if y := IDCT(dct, transformType, orthonormal); !y.IsCloseToVector(x, 0.000001) {
+				t.Errorf("IDCT type %d: %v should be %v.", transformType, y, x)
+			}
+
+			dst := DST(x, transformType, orthonormal)
+			if y := IDST(dst, transformType, orthonormal); !y.IsCloseToVector(x, 0.000001) {
+				t.Errorf("IDST type %d: %v should be %v.", transformType, y, x)
+			}
+
+			if orthonormal {
+				if !IsClose(VSumSq(dct), VSumSq(x), 0.000001) || !IsClose(VSumSq(dst), VSumSq(x), 0.000001) {
+					t.Errorf("Orthonormal type %d transforms should preserve energy.", transformType)
+				}
+			}
+		}
+	}
+}
+
+func TestTransformEmpty(t *testing.T) {
+	for _, transformType := range transformTypes {
+		if y := DCT(Vector{}, transformType, false); len(y) != 0 {
+			t.Errorf("DCT type %d of an empty vector should be empty.", transformType)
+		}
+
+		if y := IDCT(Vector{}, transformType, true); len(y) != 0 {
+			t.Errorf("IDCT type %d of an empty vector should be empty.", transformType)
+		}
+
+		if y := DST(Vector{}, transformType, false); len(y) != 0 {
+			t.Errorf("DST type %d of an empty vector should be empty.", transformType)
+		}
+	}
+
+}
@@ -0,0 +1,304 @@
+package gdsp
+
+import (
+	"math"
+)
+
+// DecimationFilter values represent the anti-aliasing filter used by Decimate.
+type DecimationFilter int
+
+// Types of decimation filters.
+const (
+	// DecimationFilterIIR uses an order 8 Chebyshev type I lowpass filter with
+	// 0.05 dB of passband ripple and a cutoff at 0.8 times the new Nyquist
+	// frequency, applied forward and backward for zero phase.
+	DecimationFilterIIR DecimationFilter = iota + 1
+
+	// DecimationFilterFIR uses a Hamming-windowed sinc lowpass filter with
+	// 20q + 1 coefficients and a cutoff at the new Nyquist frequency. The
+	// filter's delay is removed from the output.
+	DecimationFilterFIR
+)
+
+// Decimate lowpass filters the real-valued input vector with the anti-aliasing
+// filter given by filter and keeps every qth sample, starting with the first.
+// The output vector has length ceil(len(x) / q).
+//
+// The IIR filter becomes numerically unstable for large factors, so factors
+// larger than about 13 should be applied in several stages.
+func Decimate(x Vector, q int, filter DecimationFilter) Vector {
+	if q < 1 {
+		return nil
+	}
+
+	if q == 1 {
+		return x.Copy()
+	}
+
+	switch filter {
+	case DecimationFilterIIR:
+		b, a := Chebyshev1(8, 0.05, 0.8/float64(q))
+		y := FiltFilt(b, a, x)
+
+		output := MakeVector(0.0, (len(x)+q-1)/q)
+		for i := range output {
+			output[i] = y[i*q]
+		}
+		return output
+	case DecimationFilterFIR:
+		length := 20*q + 1
+		h := FIRLowpass(length, 1.0/float64(q), MakeWindow(WindowTypeHamming, length))
+		return resamplePoly(h, x, 1, q)
+	}
+	return nil
+}
+
+// cicFractionBits is the minimum number of fractional bits of the fixed-point
+// samples of a CIC filter.
+const cicFractionBits = 16
+
+// CICDecimator types decimate a signal with a cascaded integrator-comb filter.
+// The integrators run at the input rate and the combs run at the output rate.
+// State is kept between calls to Process so that a signal can be processed in
+// blocks.
+//
+// As in hardware CIC filters, samples are converted to 64-bit fixed-point
+// integers and the integrators and combs use wrapping integer arithmetic. The
+// integrators overflow on long streams, but the combs cancel the overflow
+// exactly, so the output remains accurate for any stream length.
+type CICDecimator struct {
+	rate   int
+	stages int
+	delay  int
+	scale  float64
+
+	integrators []int64
+	combs       cicCombs
+	phase       int
+}
+
+// MakeCICDecimator creates a CIC decimator that reduces the sample rate by the
+// factor rate with the given number of integrator and comb stages and
+// differential delay of the combs. Inputs are expected in the range [-1, 1] and
+// are quantized with 62 - ceil(log2((rate * delay)^stages)) fractional bits,
+// leaving room for the filter's gain. nil is returned if any argument is less
+// than one or if the gain leaves fewer than 16 fractional bits.
+func MakeCICDecimator(rate int, stages int, delay int) *CICDecimator {
+	if rate < 1 || stages < 1 || delay < 1 {
+		return nil
+	}
+
+	scale := cicScale(cicGain(rate, stages, delay))
+	if scale == 0.0 {
+		return nil
+	}
+
+	c := &CICDecimator{
+		rate:   rate,
+		stages: stages,
+		delay:  delay,
+		scale:  scale,
+	}
+
+	c.Reset()
+	return c
+}
+
+// Reset clears the decimator's integrator and comb states.
+func (c *CICDecimator) Reset() {
+	c.integrators = make([]int64, c.stages)
+	c.combs = makeCICCombs(c.stages, c.delay)
+	c.phase = 0
+}
+
+// Process filters and decimates the real-valued input vector and returns one
+// output sample for every rate input samples. The output is normalized by the
+// filter's gain, (rate * delay)^stages, so that it has unit gain at zero
+// frequency.
+func (c *CICDecimator) Process(input Vector) Vector {
+	normalization := c.scale * cicGain(c.rate, c.stages, c.delay)
+	output := MakeVector(0.0, 0)
+	for _, x := range input {
+		v := int64(math.Round(x * c.scale))
+		for i := range c.integrators {
+			c.integrators[i] += v
+			v = c.integrators[i]
+		}
+
+		if c.phase == 0 {
+			output = append(output, float64(c.combs.apply(v))/normalization)
+		}
+		c.phase = (c.phase + 1) % c.rate
+	}
+	return output
+}
+
+// CICInterpolator types interpolate a signal with a cascaded integrator-comb
+// filter. The combs run at the input rate and the integrators run at the output
+// rate. State is kept between calls to Process so that a signal can be processed
+// in blocks.
+//
+// Like CICDecimator, the filter uses 64-bit fixed-point integers and wrapping
+// integer arithmetic.
+type CICInterpolator struct {
+	rate   int
+	stages int
+	delay  int
+	scale  float64
+
+	combs       cicCombs
+	integrators []int64
+}
+
+// MakeCICInterpolator creates a CIC interpolator that increases the sample rate
+// by the factor rate with the given number of comb and integrator stages and
+// differential delay of the combs. Inputs are expected in the range [-1, 1] and
+// are quantized with 62 - ceil(log2((rate * delay)^stages / rate)) fractional
+// bits, leaving room for the filter's gain. nil is returned if any argument is
+// less than one or if the gain leaves fewer than 16 fractional bits.
+func MakeCICInterpolator(rate int, stages int, delay int) *CICInterpolator {
+	if rate < 1 || stages < 1 || delay < 1 {
+		return nil
+	}
+
+	scale := cicScale(cicGain(rate, stages, delay) / float64(rate))
+	if scale == 0.0 {
+		return nil
+	}
+
+	c := &CICInterpolator{
+		rate:   rate,
+		stages: stages,
+		delay:  delay,
+		scale:  scale,
+	}
+
+	c.Reset()
+	return c
+}
+
+// Reset clears the interpolator's comb and integrator states.
+func (c *CICInterpolator) Reset() {
+	c.combs = makeCICCombs(c.stages, c.delay)
+	c.integrators = make([]int64, c.stages)
+}
+
+// Process inserts rate - 1 zeros after each sample of the real-valued input
+// vector, filters the result and returns rate output samples for every input
+// sample. The output is normalized by the filter's gain,
+// (rate * delay)^stages / rate, so that it has unit gain at zero frequency.
+func (c *CICInterpolator) Process(input Vector) Vector {
+	normalization := c.scale * cicGain(c.rate, c.stages, c.delay) / float64(c.rate)
+	output := MakeVector(0.0, len(input)*c.rate)
+	for n, x := range input {
+		v := c.combs.apply(int64(math.Round(x * c.scale)))
+		for j := 0; j < c.rate; j++ {
+			for i := range c.integrators {
+				c.integrators[i] += v
+				v = c.integrators[i]
+			}
+			output[n*c.rate+j] = float64(v) / normalization
+			v = 0
+		}
+	}
+	return output
+}
+
+// cicCombs are the comb stages of a CIC filter, each with a delay line of the
+// filter's differential delay.
+type cicCombs struct {
+	lines [][]int64
+	index int
+}
+
+// makeCICCombs creates the given number of comb stages with the given
+// differential delay.
+func makeCICCombs(stages int, delay int) cicCombs {
+	lines := make([][]int64, stages)
+	for i := range lines {
+		lines[i] = make([]int64, delay)
+	}
+	return cicCombs{lines: lines}
+}
+
+// apply passes v through each comb stage, v[n] - v[n - delay], and returns the
+// result.
+func (c *cicCombs) apply(v int64) int64 {
+	for _, line := range c.lines {
+		previous := line[c.index]
+		line[c.index] = v
+		v -= previous
+	}
+	c.index = (c.index + 1) % len(c.lines[0])
+	return v
+}
+
+// CICCompensator designs a linear-phase FIR filter of the given odd length that
+// compensates for the passband droop of a CIC filter with the given rate, number
+// of stages and differential delay. The compensator runs at the low sample rate
+// of the CIC filter, after a decimator or before an interpolator.
+//
+// The filter's magnitude response is the inverse of the CIC filter's up to
+// cutoff and zero above it, where cutoff is normalized so that 1 is the Nyquist
+// frequency of the low sample rate. The response is designed by frequency
+// sampling and smoothed with a Hamming window, and the coefficients are scaled
+// to have unit gain at zero frequency.
+func CICCompensator(rate int, stages int, delay int, length int, cutoff float64) Vector {
+	if rate < 1 || stages < 1 || delay < 1 || length < 1 || length%2 == 0 {
+		return nil
+	}
+
+	nfft := 1
+	for nfft < 16*length {
+		nfft <<= 1
+	}
+
+	d := MakeVectorComplex(0.0, nfft)
+	for k := 0; k <= nfft/2; k++ {
+		f := float64(k) / float64(nfft)
+		if 2.0*f > cutoff {
+			break
+		}
+
+		if m := cicMagnitude(rate, stages, delay, f); m > 0.0 {
+			d[k] = complex(1.0/m, 0.0)
+			d[(nfft-k)%nfft] = d[k]
+		}
+	}
+
+	impulse := IFFT(d).Real()
+	w := MakeWindow(WindowTypeHamming, length)
+	center := (length - 1) / 2
+	h := MakeVector(0.0, length)
+	for i := range h {
+		h[i] = impulse[(i-center+nfft)%nfft] * w[i]
+	}
+	return VSDiv(h, VESum(h))
+}
+
+// cicScale returns the fixed-point scale of a CIC filter's input samples, which
+// leaves enough integer bits for inputs in the range [-1, 1] multiplied by the
+// filter's gain, or zero if fewer than cicFractionBits fractional bits remain.
+func cicScale(gain float64) float64 {
+	bits := 62 - int(math.Ceil(math.Log2(gain)))
+	if bits < cicFractionBits {
+		return 0.0
+	}
+	return math.Ldexp(1.0, bits)
+}
+
+// cicGain returns the gain at zero frequency of a CIC decimator.
+func cicGain(rate int, stages int, delay int) float64 {
+	return math.Pow(float64(rate*delay), float64(stages))
+}
+
+// cicMagnitude returns the normalized magnitude response of a CIC filter at the
+// frequency f, in cycles per sample at the low sample rate.
+func cicMagnitude(rate int, stages int, delay int, f float64) float64 {
+	if f == 0.0 {
+		return 1.0
+	}
+
+	m := math.Sin(math.Pi*float64(delay)*f) / (float64(rate*delay) * math.Sin(math.Pi*f/float64(rate)))
+	return math.Pow(math.Abs(m), float64(stages))
+}
@@ -0,0 +1,178 @@
+package gdsp
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestChebyshev1(t *testing.T) {
+	for _, order := range []int{3, 4, 8} {
+		b, a := Chebyshev1(order, 1.0, 0.3)
+
+		dc := cmplx.Abs(frequencyResponse(b, a, 0.0))
+		expectedDC := 1.0
+		if order%2 == 0 {
+			expectedDC = DB2Mag(Vector{-1.0})[0]
+		}
+		if !IsClose(dc, expectedDC, 0.000001) {
+			t.Errorf("Order %d: DC gain %f should be %f.", order, dc, expectedDC)
+		}
+
+		if g := Mag2dB(Vector{cmplx.Abs(frequencyResponse(b, a, 0.3))})[0]; !IsClose(g, -1.0, 0.000001) {
+			t.Errorf("Order %d: gain at cutoff %f dB should be -1 dB.", order, g)
+		}
+
+		for _, f := range []float64{0.05, 0.1, 0.2, 0.25} {
+			if g := cmplx.Abs(frequencyResponse(b, a, f)); g > 1.000001 || g < DB2Mag(Vector{-1.0})[0]-0.000001 {
+				t.Errorf("Order %d: passband gain %f at %f is outside the ripple.", order, g, f)
+			}
+		}
+
+		if g := cmplx.Abs(frequencyResponse(b, a, 0.8)); g > 0.01 {
+			t.Errorf("Order %d: stopband gain %f should be small.", order, g)
+		}
+	}
+}
+
+func TestFilterSteadyState(t *testing.T) {
+	b, a := Chebyshev1(4, 0.5, 0.2)
+	x := MakeVector(2.0, 20)
+	y, _ := Filter(b, a, x, VSMul(FilterSteadyState(b, a), x[0]))
+
+	dc := 2.0 * real(frequencyResponse(b, a, 0.0))
+	if !y.IsCloseToVector(MakeVector(dc, len(y)), 0.000001) {
+		t.Errorf("Step response %v should be constant %f.", y, dc)
+	}
+}
+
+func TestFiltFilt(t *testing.T) {
+	b, a := Chebyshev1(8, 0.05, 0.4)
+	x := MakeVector(0.0, 200)
+	for i := range x {
+		x[i] = math.Sin(2.0*math.Pi*float64(i)/40.0) + 0.5*math.Sin(2.0*math.Pi*float64(i)/3.0)
+	}
+
+	y := FiltFilt(b, a, x)
+	if len(y) != len(x) {
+		t.Fatalf("Length %d should be %d.", len(y), len(x))
+	}
+
+	for i := 20; i < len(y)-20; i++ {
+		expected := math.Sin(2.0 * math.Pi * float64(i) / 40.0)
+		if !IsClose(y[i], expected, 0.02) {
+			t.Errorf("%f at %d should be %f.", y[i], i, expected)
+			break
+		}
+	}
+}
+
+func TestDecimate(t *testing.T) {
+	x := MakeVector(0.0, 400)
+	for i := range x {
+		x[i] = math.Sin(2.0*math.Pi*float64(i)/80.0) + 0.5*math.Sin(2.0*math.Pi*float64(i)*0.45)
+	}
+
+	for _, filter := range []DecimationFilter{DecimationFilterIIR, DecimationFilterFIR} {
+		for _, q := range []int{2, 3, 5} {
+			y := Decimate(x, q, filter)
+			if len(y) != (len(x)+q-1)/q {
+				t.Fatalf("Filter %d, q %d: length %d should be %d.", filter, q, len(y), (len(x)+q-1)/q)
+			}
+
+			for i := len(y) / 4; i < 3*len(y)/4; i++ {
+				expected := math.Sin(2.0 * math.Pi * float64(i*q) / 80.0)
+				if !IsClose(y[i], expected, 0.01) {
+					t.Errorf("Filter %d, q %d: %f at %d should be %f.", filter, q, y[i], i, expected)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestCICDecimator(t *testing.T) {
+	x := MakeVector(0.0, 240)
+	for i := range x {
+		x[i] = 0.5 + 0.4*math.Sin(2.0*math.Pi*float64(i)/7.0)
+	}
+
+	whole := MakeCICDecimator(4, 3, 1).Process(x)
+	if len(whole) != len(x)/4 {
+		t.Fatalf("Length %d should be %d.", len(whole), len(x)/4)
+	}
+
+	c := MakeCICDecimator(4, 3, 1)
+	var blocks Vector
+	for _, size := range []int{7, 50, 1, 82, 100} {
+		blocks = append(blocks, c.Process(x[:size])...)
+		x = x[size:]
+	}
+
+	if !blocks.IsCloseToVector(whole, 0.000001) {
+		t.Errorf("Block output %v should be %v.", blocks, whole)
+	}
+
+	dc := MakeCICDecimator(8, 4, 2).Process(MakeVector(0.75, 400))
+	if !IsClose(dc[len(dc)-1], 0.75, 0.000001) {
+		t.Errorf("DC output %f should be 0.75.", dc[len(dc)-1])
+	}
+}
+
+func TestCICInterpolator(t *testing.T) {
+	x := Vector{0.25, -0.5, 0.125, 0.75, 0.0, 0.375, -0.25, 0.5}
+
+	whole := MakeCICInterpolator(3, 2, 1).Process(x)
+	if len(whole) != 3*len(x) {
+		t.Fatalf("Length %d should be %d.", len(whole), 3*len(x))
+	}
+
+	c := MakeCICInterpolator(3, 2, 1)
+	blocks := append(c.Process(x[:3]), c.Process(x[3:])...)
+	if !blocks.IsCloseToVector(whole, 0.000001) {
+		t.Errorf("Block output %v should be %v.", blocks, whole)
+	}
+
+	dc := MakeCICInterpolator(5, 3, 1).Process(MakeVector(-0.5, 20))
+	if !IsClose(dc[len(dc)-1], -0.5, 0.000001) {
+		t.Errorf("DC output %f should be -0.5.", dc[len(dc)-1])
+	}
+}
+
+func TestCICLongStream(t *testing.T) {
+	d := MakeCICDecimator(8, 4, 1)
+	block := MakeVector(1.0, 4096)
+	for i := 0; i < 2048; i++ {
+		y := d.Process(block)
+		if !IsClose(y[len(y)-1], 1.0, 0.000001) {
+			t.Fatalf("Decimator output %f after %d samples should be 1.", y[len(y)-1], (i+1)*len(block))
+		}
+	}
+
+	u := MakeCICInterpolator(8, 4, 1)
+	block = MakeVector(-1.0, 4096)
+	for i := 0; i < 256; i++ {
+		y := u.Process(block)
+		if !IsClose(y[len(y)-1], -1.0, 0.000001) {
+			t.Fatalf("Interpolator output %f after %d samples should be -1.", y[len(y)-1], (i+1)*len(block))
+		}
+	}
+
+	if c := MakeCICDecimator(1<<20, 3, 1); c != nil {
+		t.Errorf("A gain that leaves too few fractional bits should return nil.")
+	}
+}
+
+func TestCICCompensator(t *testing.T) {
+	h := CICCompensator(8, 4, 1, 31, 0.5)
+	if len(h) != 31 {
+		t.Fatalf("Length %d should be 31.", len(h))
+	}
+
+	for _, f := range []float64{0.0, 0.1, 0.2, 0.3, 0.4} {
+		g := cmplx.Abs(frequencyResponse(h, Vector{1.0}, f)) * cicMagnitude(8, 4, 1, f/2.0)
+		if !IsClose(g, 1.0, 0.02) {
+			t.Errorf("Compensated gain %f at %f should be 1.", g, f)
+		}
+	}
+}
@@ -35,14 +35,23 @@ func DFT(input VectorComplex, forward bool) VectorComplex {
 	return output
 }
 
+// bluesteinLength is the odd length above which FFT uses Bluestein's algorithm
+// instead of a DFT.
+const bluesteinLength = 64
+
 // FFT performs a discrete Fourier transform on the complex-valued input vector
-// using the Cooley-Turkey FFT algorithm. For an inverse FFT, see the IFFT function.
+// using the Cooley-Turkey FFT algorithm. Odd length inputs, or odd length factors
+// of the input, are transformed with a DFT when they are short and with
+// Bluestein's algorithm otherwise. For an inverse FFT, see the IFFT function.
 func FFT(input VectorComplex) VectorComplex {
 	if len(input) == 1 {
 		return input
 	}
 
 	if len(input)%2 != 0 {
+		if len(input) > bluesteinLength {
+			return FFTBluestein(input)
+		}
 		return DFT(input, true)
 	}
 
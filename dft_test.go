@@ -60,3 +60,14 @@ func TestInverseDFT(t *testing.T) {
 		}
 	}
 }
+
+func TestFFTOddLength(t *testing.T) {
+	v := MakeVectorComplex(0.0, 2*101)
+	for i := range v {
+		v[i] = complex(float64(i%5), float64(i%3))
+	}
+
+	if !FFT(v).IsCloseToVectorC(DFT(v, true), 0.000001) {
+		t.Error("FFT should match the DFT.")
+	}
+}
@@ -0,0 +1,61 @@
+package gdsp
+
+// EdgeMode values represent how filters extend a signal past its ends.
+type EdgeMode int
+
+// Types of edge modes.
+const (
+	// EdgeModeInterp fits a polynomial to the samples at each end of the signal
+	// instead of extending it. It is only supported by SavitzkyGolayFilter.
+	EdgeModeInterp EdgeMode = iota + 1
+
+	// EdgeModeMirror reflects the signal about its end samples, so that
+	// x[-k] = x[k].
+	EdgeModeMirror
+
+	// EdgeModeNearest repeats the end samples.
+	EdgeModeNearest
+
+	// EdgeModeWrap treats the signal as periodic.
+	EdgeModeWrap
+
+	// EdgeModeConstant extends the signal with zeros.
+	EdgeModeConstant
+)
+
+// edgeIndex returns the index of the sample of a signal of length n that
+// extends it to index i with the given edge mode, or -1 if the extended sample is
+// zero.
+func edgeIndex(i int, n int, mode EdgeMode) int {
+	if i >= 0 && i < n {
+		return i
+	}
+
+	switch mode {
+	case EdgeModeMirror:
+		if n == 1 {
+			return 0
+		}
+
+		period := 2 * (n - 1)
+		i = ((i % period) + period) % period
+		if i >= n {
+			i = period - i
+		}
+		return i
+	case EdgeModeNearest:
+		return MinI(MaxI(i, 0), n-1)
+	case EdgeModeWrap:
+		return ((i % n) + n) % n
+	}
+	return -1
+}
+
+// extended returns the sample of x at index i, extended past its ends with the
+// given edge mode.
+func extended(x Vector, i int, mode EdgeMode) float64 {
+	if j := edgeIndex(i, len(x), mode); j >= 0 {
+		return x[j]
+	}
+	return 0.0
+}
@@ -1,18 +1,28 @@
 package gdsp
 
+// ExtrapolationModel values represent the signal model used to extrapolate a
+// signal.
+type ExtrapolationModel int
+
+// Types of extrapolation models.
+const (
+	// ExtrapolationModelAutoregressive models the signal as an autoregressive
+	// process using Burg's method.
+	ExtrapolationModelAutoregressive ExtrapolationModel = iota + 1
+
+	// ExtrapolationModelProny models the signal as a sum of damped exponentials
+	// using Prony's method.
+	ExtrapolationModelProny
+
+	// ExtrapolationModelMatrixPencil models the signal as a sum of damped
+	// exponentials using the matrix pencil method.
+	ExtrapolationModelMatrixPencil
+)
+
 // Extrapolate extrapolates the given real-valued signal by n samples using an autoregressive
 // model.
 func Extrapolate(input Vector, n int) Vector {
-	if !input.IsZero() {
-		aR, _ := Arburg(input, len(input)-1)
-		bR := MakeVector(1.0, 1).PaddedTrailing(0.0, len(aR)-1)
-		yR := input.SubVector(1, len(input)).Reversed()
-		zR := Filtic(bR, aR, yR, nil)
-		yeR, _ := Filter(bR, aR, MakeVector(0.0, n), zR)
-		return yeR
-	}
-
-	return MakeVector(0.0, n)
+	return ExtrapolateWithModel(input, n, ExtrapolationModelAutoregressive, len(input)-1)
 }
 
 // ExtrapolateC extrapolates the given complex-valued signal by n samples using
@@ -26,6 +36,62 @@ func ExtrapolateC(input VectorComplex, count int) VectorComplex {
 	return ye
 }
 
+// ExtrapolateWithModel extrapolates the given real-valued signal by n samples
+// using the given model of order p. For the autoregressive model p is the model
+// order, and for the exponential models it is the number of complex
+// exponentials, which is twice the number of damped sinusoids.
+//
+// The matrix pencil model uses a pencil parameter of len(input)/3, within the
+// range of len(input)/3 to len(input)/2 where the method is least sensitive to
+// noise. To use another pencil parameter, fit the model with MatrixPencil and
+// evaluate it directly.
+func ExtrapolateWithModel(input Vector, n int, model ExtrapolationModel, p int) Vector {
+	switch model {
+	case ExtrapolationModelAutoregressive:
+		if input.IsZero() {
+			return MakeVector(0.0, n)
+		}
+
+		aR, _ := Arburg(input, p)
+		bR := MakeVector(1.0, 1).PaddedTrailing(0.0, len(aR)-1)
+		yR := input.Reversed()
+		zR := Filtic(bR, aR, yR, nil)
+		yeR, _ := Filter(bR, aR, MakeVector(0.0, n), zR)
+		return yeR
+	case ExtrapolationModelProny:
+		return Prony(input, p).Evaluate(len(input), n).Real()
+	case ExtrapolationModelMatrixPencil:
+		return MatrixPencil(input, p, len(input)/3).Evaluate(len(input), n).Real()
+	}
+	return nil
+}
+
+// ExtrapolateWithModelC extrapolates the given complex-valued signal by n
+// samples using the given model of order p. For the autoregressive model p is the
+// model order, and for the exponential models it is the number of complex
+// exponentials. The matrix pencil model uses a pencil parameter of
+// len(input)/3, as in ExtrapolateWithModel.
+func ExtrapolateWithModelC(input VectorComplex, n int, model ExtrapolationModel, p int) VectorComplex {
+	switch model {
+	case ExtrapolationModelAutoregressive:
+		if input.IsZero() {
+			return MakeVectorComplex(0.0, n)
+		}
+
+		a, _ := ArburgC(input, p)
+		b := MakeVectorComplex(ComplexRI(1), 1).PaddedTrailing(0.0, len(a)-1)
+		y := input.Reversed()
+		z := FilticC(b, a, y, nil)
+		ye, _ := FilterC(b, a, MakeVectorComplex(0.0, n), z)
+		return ye
+	case ExtrapolationModelProny:
+		return PronyC(input, p).Evaluate(len(input), n)
+	case ExtrapolationModelMatrixPencil:
+		return MatrixPencilC(input, p, len(input)/3).Evaluate(len(input), n)
+	}
+	return nil
+}
+
 // FrequencyExtrapolate extrapolates a signal by extrapolating its frequency component signals.
 func FrequencyExtrapolate(input Vector, count int, windowLength int, windowType WindowType) (Vector, Vector) {
 	s := Spectrogram(input, windowLength, windowType)
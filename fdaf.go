@@ -0,0 +1,203 @@
+package gdsp
+
+import (
+	"math/cmplx"
+)
+
+// fdafPowerSmoothing is the smoothing factor of the FDAF's input power estimate.
+// The estimate starts from the power of the first block, so that early blocks
+// are not adapted with a step size inflated by a zero initial estimate.
+const fdafPowerSmoothing = 0.9
+
+// StepSizeControl functions return the step size used to adapt an FDAF's
+// weights for a block, given the block's input, desired signal and error.
+// Returning zero skips the adaptation.
+type StepSizeControl func(x Vector, d Vector, e Vector) float64
+
+// FDAF types are partitioned-block frequency-domain adaptive filters. The filter
+// has partitions * blockSize weights, split in to partitions of blockSize
+// weights that are each applied in the frequency domain with FFTs of length
+// 2 * blockSize using overlap-save framing. The weights are adapted once per
+// block with a per-bin normalized LMS update.
+//
+// With the constrained update, the gradient of each partition is transformed
+// to the time domain and its second half is zeroed so that the filter remains a
+// linear convolution, at the cost of two more FFTs per partition. The
+// unconstrained update skips the constraint, converges more slowly and typically
+// needs a smaller step size to remain stable.
+type FDAF struct {
+	blockSize      int
+	partitions     int
+	stepSize       float64
+	regularization float64
+	constrained    bool
+	control        StepSizeControl
+	frozen         bool
+
+	weights  []VectorComplex
+	spectra  []VectorComplex
+	power    Vector
+	primed   bool
+	previous Vector
+}
+
+// MakeFDAF creates a frequency-domain adaptive filter with the given block size,
+// number of partitions, step size, mu, regularization, epsilon, and update type.
+// The step size should be in the range (0, 1). Each bin is adapted with the step
+// size mu / (epsilon + P), where P is a smoothed estimate of the input power in
+// the bin, starting from the power of the first block. nil is returned if
+// blockSize or partitions is less than one.
+func MakeFDAF(blockSize int, partitions int, stepSize float64, regularization float64, constrained bool) *FDAF {
+	if blockSize < 1 || partitions < 1 {
+		return nil
+	}
+
+	f := &FDAF{
+		blockSize:      blockSize,
+		partitions:     partitions,
+		stepSize:       stepSize,
+		regularization: regularization,
+		constrained:    constrained,
+	}
+
+	f.Reset()
+	return f
+}
+
+// Reset sets the filter's weights to zero and clears its input history and power
+// estimate.
+func (f *FDAF) Reset() {
+	n := 2 * f.blockSize
+	f.weights = make([]VectorComplex, f.partitions)
+	f.spectra = make([]VectorComplex, f.partitions)
+	for p := range f.weights {
+		f.weights[p] = MakeVectorComplex(0.0, n)
+		f.spectra[p] = MakeVectorComplex(0.0, n)
+	}
+
+	f.power = MakeVector(0.0, n)
+	f.primed = false
+	f.previous = MakeVector(0.0, f.blockSize)
+}
+
+// SetStepSize sets the step size used when no step size control is set.
+func (f *FDAF) SetStepSize(stepSize float64) {
+	f.stepSize = stepSize
+}
+
+// SetStepSizeControl sets a function that returns the step size for each block,
+// overriding the fixed step size. Set it to nil to use the fixed step size.
+func (f *FDAF) SetStepSizeControl(control StepSizeControl) {
+	f.control = control
+}
+
+// SetFrozen freezes or unfreezes adaptation. While frozen, the filter continues
+// to filter its input but its weights are not updated, such as during double
+// talk in an echo canceller.
+func (f *FDAF) SetFrozen(frozen bool) {
+	f.frozen = frozen
+}
+
+// Frozen returns whether adaptation is frozen.
+func (f *FDAF) Frozen() bool {
+	return f.frozen
+}
+
+// Weights returns the filter's current time-domain impulse response, which has
+// partitions * blockSize samples. With the unconstrained update, the part of each
+// partition's response that wraps around is discarded.
+func (f *FDAF) Weights() Vector {
+	var w Vector
+	for _, W := range f.weights {
+		w = append(w, IFFT(W).Real()[:f.blockSize]...)
+	}
+	return w
+}
+
+// Update filters a block of blockSize samples of the input x, adapts the weights
+// towards the block of the desired signal d, unless adaptation is frozen, and
+// returns the filter's output and error for the block. nil is returned if either
+// block does not have blockSize samples.
+func (f *FDAF) Update(x Vector, d Vector) (Vector, Vector) {
+	b := f.blockSize
+	if len(x) != b || len(d) != b {
+		return nil, nil
+	}
+
+	copy(f.spectra[1:], f.spectra)
+	f.spectra[0] = FFT(append(f.previous.Copy(), x...).ToComplex())
+	f.previous = x.Copy()
+
+	Y := MakeVectorComplex(0.0, 2*b)
+	for p, X := range f.spectra {
+		Y = VAddC(Y, VMulEC(X, f.weights[p]))
+	}
+
+	y := IFFT(Y).Real()[b:]
+	e := VSub(d, y)
+
+	if f.primed {
+		f.power = VAdd(VSMul(f.power, fdafPowerSmoothing), VSMul(VSqMagC(f.spectra[0]), 1.0-fdafPowerSmoothing))
+	} else {
+		f.power = VSqMagC(f.spectra[0])
+		f.primed = true
+	}
+
+	mu := f.stepSize
+	if f.control != nil {
+		mu = f.control(x, d, e)
+	}
+
+	if !f.frozen && mu != 0.0 {
+		f.adapt(e, mu)
+	}
+	return y, e
+}
+
+// Process filters the input x and adapts the weights towards the desired signal
+// d one block at a time, and returns the filter's output and error. nil is
+// returned if the vectors have different lengths or their length is not a
+// multiple of the block size.
+func (f *FDAF) Process(x Vector, d Vector) (Vector, Vector) {
+	if len(x) != len(d) || len(x)%f.blockSize != 0 {
+		return nil, nil
+	}
+
+	y := MakeVector(0.0, 0)
+	e := MakeVector(0.0, 0)
+	for i := 0; i < len(x); i += f.blockSize {
+		yb, eb := f.Update(x[i:i+f.blockSize], d[i:i+f.blockSize])
+		y = append(y, yb...)
+		e = append(e, eb...)
+	}
+	return y, e
+}
+
+// adapt updates the weights of each partition with the error block e and step
+// size mu.
+func (f *FDAF) adapt(e Vector, mu float64) {
+	b := f.blockSize
+	E := FFT(e.ToComplex().PaddedLeading(0.0, b))
+
+	step := MakeVectorComplex(0.0, 2*b)
+	for k := range step {
+		step[k] = E[k] * complex(mu/(f.power[k]+f.regularization), 0.0)
+	}
+
+	for p, X := range f.spectra {
+		G := make(VectorComplex, len(X))
+		for k := range G {
+			G[k] = cmplx.Conj(X[k]) * step[k]
+		}
+
+		if f.constrained {
+			g := IFFT(G)
+			for i := b; i < 2*b; i++ {
+				g[i] = 0.0
+			}
+			G = FFT(g)
+		}
+
+		f.weights[p] = VAddC(f.weights[p], G)
+	}
+}
@@ -0,0 +1,84 @@
+package gdsp
+
+import (
+	"testing"
+)
+
+func TestFDAFIdentification(t *testing.T) {
+	h := VSMul(noise(64, 8.0), 0.5)
+	x := noise(16384, 9.0)
+	d, _ := Filter(h, Vector{1.0}, x, nil)
+
+	f := MakeFDAF(16, 4, 0.5, 0.000001, true)
+	y, e := f.Process(x, d)
+	if len(y) != len(x) || len(e) != len(x) {
+		t.Fatalf("Output lengths should be %d.", len(x))
+	}
+
+	if w := f.Weights(); !w.IsCloseToVector(h, 0.001) {
+		t.Errorf("Weights %v should be %v.", w, h)
+	}
+
+	tail := len(e) - 256
+	if !y.SubVector(tail, len(y)).IsCloseToVector(d.SubVector(tail, len(d)), 0.001) {
+		t.Errorf("Output should match the desired signal after convergence.")
+	}
+
+	u := MakeFDAF(16, 4, 0.2, 0.000001, false)
+	_, eu := u.Process(x, d)
+	if power := VSumSq(eu.SubVector(tail, len(eu))) / VSumSq(d.SubVector(tail, len(d))); power > 0.001 {
+		t.Errorf("Unconstrained error power %f should be small.", power)
+	}
+}
+
+func TestFDAFControl(t *testing.T) {
+	h := Vector{0.5, -0.25, 0.125}
+	x := noise(256, 10.0)
+	d, _ := Filter(h, Vector{1.0}, x, nil)
+
+	f := MakeFDAF(8, 2, 0.5, 0.000001, true)
+	f.Process(x[:128], d[:128])
+	w := f.Weights()
+
+	f.SetFrozen(true)
+	if !f.Frozen() {
+		t.Errorf("Filter should be frozen.")
+	}
+	f.Process(x[128:], d[128:])
+	if !f.Weights().IsCloseToVector(w, 0.0000001) {
+		t.Errorf("Frozen weights should not change.")
+	}
+
+	f.SetFrozen(false)
+	blocks := 0
+	f.SetStepSizeControl(func(x Vector, d Vector, e Vector) float64 {
+		blocks++
+		return 0.0
+	})
+	f.Process(x[128:], d[128:])
+	if blocks != 16 || !f.Weights().IsCloseToVector(w, 0.0000001) {
+		t.Errorf("Control should be called for %d blocks, not %d, and return a zero step size.", 16, blocks)
+	}
+
+	if y, _ := f.Process(x[:10], d[:10]); y != nil {
+		t.Errorf("A length that is not a multiple of the block size should return nil.")
+	}
+}
+
+func TestFDAFInitialPower(t *testing.T) {
+	x := noise(32, 11.0)
+	f := MakeFDAF(16, 2, 0.5, 0.000001, true)
+
+	for i := 0; i < 2; i++ {
+		f.Update(x[:16], x[:16])
+		if !f.power.IsCloseToVector(VSqMagC(f.spectra[0]), 0.000001) {
+			t.Errorf("The power estimate should start from the power of the first block.")
+		}
+
+		f.Update(x[16:], x[16:])
+		if f.power.IsCloseToVector(VSqMagC(f.spectra[0]), 0.000001) {
+			t.Errorf("The power estimate should be smoothed after the first block.")
+		}
+		f.Reset()
+	}
+}
@@ -0,0 +1,144 @@
+package gdsp
+
+// ConvolutionMode values represent the portion of a convolution to return.
+type ConvolutionMode int
+
+// Types of convolution modes.
+const (
+	// ConvolutionModeFull returns the full convolution.
+	ConvolutionModeFull ConvolutionMode = iota + 1
+
+	// ConvolutionModeSame returns the central part of the convolution with the
+	// same size as the first input.
+	ConvolutionModeSame
+
+	// ConvolutionModeValid returns the part of the convolution computed without
+	// zero-padding the first input.
+	ConvolutionModeValid
+)
+
+// FFT2 performs a two-dimensional discrete Fourier transform on the
+// complex-valued input matrix by performing an FFT along its rows and then along
+// its columns. For an inverse FFT, see the IFFT2 function.
+func FFT2(input MatrixComplex) MatrixComplex {
+	return transform2(input, FFT)
+}
+
+// IFFT2 performs a two-dimensional inverse discrete Fourier transform on the
+// complex-valued input matrix by performing an IFFT along its rows and then along
+// its columns. For a forward FFT, see the FFT2 function.
+func IFFT2(input MatrixComplex) MatrixComplex {
+	return transform2(input, IFFT)
+}
+
+// Conv2 performs two-dimensional convolution on real-valued matrices a and b
+// and returns the portion of the result given by mode.
+func Conv2(a Matrix, b Matrix, mode ConvolutionMode) Matrix {
+	return Conv2C(a.ToComplex(), b.ToComplex(), mode).Real()
+}
+
+// Conv2C performs two-dimensional convolution on complex-valued matrices a and b
+// using FFT2 and returns the portion of the result given by mode. The full
+// convolution of an m1 by n1 matrix and an m2 by n2 matrix is
+// (m1 + m2 - 1) by (n1 + n2 - 1).
+func Conv2C(a MatrixComplex, b MatrixComplex, mode ConvolutionMode) MatrixComplex {
+	if len(a) == 0 || len(a[0]) == 0 || len(b) == 0 || len(b[0]) == 0 {
+		return nil
+	}
+
+	ra, ca := len(a), len(a[0])
+	rb, cb := len(b), len(b[0])
+	rows := ra + rb - 1
+	columns := ca + cb - 1
+
+	fa := FFT2(a.Padded(rows, columns))
+	fb := FFT2(b.Padded(rows, columns))
+	for i := range fa {
+		fa[i] = VMulEC(fa[i], fb[i])
+	}
+	full := IFFT2(fa)
+
+	switch mode {
+	case ConvolutionModeFull:
+		return full
+	case ConvolutionModeSame:
+		return full.SubMatrix((rb-1)/2, (cb-1)/2, ra, ca)
+	case ConvolutionModeValid:
+		return full.SubMatrix(rb-1, cb-1, MaxI(ra-rb+1, 0), MaxI(ca-cb+1, 0))
+	}
+	return nil
+}
+
+// XCorr2 performs two-dimensional cross-correlation on real-valued matrices a
+// and b. The output matrix is (m1 + m2 - 1) by (n1 + n2 - 1) and the element at
+// row m2 - 1 and column n2 - 1 is the correlation at zero lag.
+func XCorr2(a Matrix, b Matrix) Matrix {
+	return XCorr2C(a.ToComplex(), b.ToComplex()).Real()
+}
+
+// XCorr2C performs two-dimensional cross-correlation on complex-valued matrices
+// a and b by convolving a with the conjugate of b rotated by 180 degrees. The
+// output matrix is (m1 + m2 - 1) by (n1 + n2 - 1) and the element at row
+// m2 - 1 and column n2 - 1 is the correlation at zero lag.
+func XCorr2C(a MatrixComplex, b MatrixComplex) MatrixComplex {
+	rotated := make(MatrixComplex, len(b))
+	for i, row := range b {
+		rotated[len(b)-1-i] = row.Reversed().Conj()
+	}
+	return Conv2C(a, rotated, ConvolutionModeFull)
+}
+
+// MakeWindow2 creates and returns a two-dimensional window with the given number
+// of rows and columns. The window is the outer product of the one-dimensional
+// windows given by windowType along each dimension.
+func MakeWindow2(windowType WindowType, rows int, columns int) Matrix {
+	wr := MakeWindow(windowType, rows)
+	wc := MakeWindow(windowType, columns)
+	if wr == nil || wc == nil {
+		return nil
+	}
+
+	w := MakeMatrix(0.0, rows, columns)
+	for i := range w {
+		w[i] = VSMul(wc, wr[i])
+	}
+	return w
+}
+
+// Window2 applies the two-dimensional window function given by windowType to the
+// input matrix.
+func Window2(windowType WindowType, input MatrixComplex) MatrixComplex {
+	if len(input) == 0 {
+		return nil
+	}
+
+	w := MakeWindow2(windowType, len(input), len(input[0]))
+	if w == nil {
+		return nil
+	}
+
+	output := make(MatrixComplex, len(input))
+	for i, row := range input {
+		output[i] = VMulEC(w[i].ToComplex(), row)
+	}
+	return output
+}
+
+// transform2 applies the one-dimensional transform t along the rows and then the
+// columns of input.
+func transform2(input MatrixComplex, t func(VectorComplex) VectorComplex) MatrixComplex {
+	if len(input) == 0 || len(input[0]) == 0 {
+		return nil
+	}
+
+	rows := make(MatrixComplex, len(input))
+	for i, row := range input {
+		rows[i] = t(row.Copy())
+	}
+
+	columns := rows.FlipOrderComplex()
+	for i, column := range columns {
+		columns[i] = t(column)
+	}
+	return columns.FlipOrderComplex()
+}
@@ -0,0 +1,117 @@
+package gdsp
+
+import (
+	"math"
+	"testing"
+)
+
+func isCloseToMatrix(a Matrix, b Matrix, tolerance float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !a[i].IsCloseToVector(b[i], tolerance) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestFFT2(t *testing.T) {
+	m := MatrixComplex{
+		VectorComplex{1.0, 2.0, complex(0.0, 1.0)},
+		VectorComplex{3.0, -1.0, 2.0},
+	}
+
+	f := FFT2(m)
+	for k := range f {
+		for l := range f[k] {
+			x := complex(0.0, 0.0)
+			for i := range m {
+				for j := range m[i] {
+					theta := -2.0 * math.Pi * (float64(i*k)/2.0 + float64(j*l)/3.0)
+					x += m[i][j] * complex(math.Cos(theta), math.Sin(theta))
+				}
+			}
+
+			if !IsCloseC(f[k][l], x, 0.000001) {
+				t.Errorf("%v at (%d, %d) should be %v.", f[k][l], k, l, x)
+			}
+		}
+	}
+
+	inverse := IFFT2(f)
+	for i := range m {
+		if !inverse[i].IsCloseToVectorC(m[i], 0.000001) {
+			t.Errorf("%v should be %v.", inverse, m)
+		}
+	}
+}
+
+func TestConv2Matrix(t *testing.T) {
+	a := Matrix{
+		Vector{1.0, 2.0, 3.0},
+		Vector{4.0, 5.0, 6.0},
+		Vector{7.0, 8.0, 9.0},
+	}
+	b := Matrix{
+		Vector{1.0, 0.0},
+		Vector{0.0, -1.0},
+	}
+
+	full := Matrix{
+		Vector{1.0, 2.0, 3.0, 0.0},
+		Vector{4.0, 4.0, 4.0, -3.0},
+		Vector{7.0, 4.0, 4.0, -6.0},
+		Vector{0.0, -7.0, -8.0, -9.0},
+	}
+	same := Matrix{
+		Vector{1.0, 2.0, 3.0},
+		Vector{4.0, 4.0, 4.0},
+		Vector{7.0, 4.0, 4.0},
+	}
+	valid := Matrix{
+		Vector{4.0, 4.0},
+		Vector{4.0, 4.0},
+	}
+
+	if c := Conv2(a, b, ConvolutionModeFull); !isCloseToMatrix(c, full, 0.000001) {
+		t.Errorf("%v should be %v.", c, full)
+	}
+
+	if c := Conv2(a, b, ConvolutionModeSame); !isCloseToMatrix(c, same, 0.000001) {
+		t.Errorf("%v should be %v.", c, same)
+	}
+
+	if c := Conv2(a, b, ConvolutionModeValid); !isCloseToMatrix(c, valid, 0.000001) {
+		t.Errorf("%v should be %v.", c, valid)
+	}
+}
+
+func TestXCorr2Matrix(t *testing.T) {
+	a := Matrix{
+		Vector{1.0, 2.0},
+		Vector{3.0, 4.0},
+	}
+
+	x := XCorr2(a, a)
+	if len(x) != 3 || len(x[0]) != 3 {
+		t.Fatalf("Cross-correlation should be 3 by 3.")
+	}
+
+	if !IsClose(x[1][1], 30.0, 0.000001) || !IsClose(x[0][0], 4.0, 0.000001) || !IsClose(x[2][2], 4.0, 0.000001) {
+		t.Errorf("%v is incorrect.", x)
+	}
+}
+
+func TestMakeWindow2(t *testing.T) {
+	w := MakeWindow2(WindowTypeHann, 5, 7)
+	if len(w) != 5 || len(w[0]) != 7 {
+		t.Fatalf("Window should be 5 by 7.")
+	}
+
+	if !IsClose(w[2][3], 1.0, 0.000001) || w[0][3] != 0.0 || w[2][0] != 0.0 {
+		t.Errorf("%v is incorrect.", w)
+	}
+}
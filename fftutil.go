@@ -0,0 +1,64 @@
+package gdsp
+
+// FFTShift shifts the zero-frequency component of the real-valued FFT-ordered
+// vector to the center of the vector.
+func FFTShift(v Vector) Vector {
+	return rotate(v, (len(v)+1)/2)
+}
+
+// FFTShiftC shifts the zero-frequency component of the complex-valued
+// FFT-ordered vector to the center of the vector.
+func FFTShiftC(v VectorComplex) VectorComplex {
+	return rotateC(v, (len(v)+1)/2)
+}
+
+// IFFTShift undoes FFTShift on the real-valued vector.
+func IFFTShift(v Vector) Vector {
+	return rotate(v, len(v)/2)
+}
+
+// IFFTShiftC undoes FFTShiftC on the complex-valued vector.
+func IFFTShiftC(v VectorComplex) VectorComplex {
+	return rotateC(v, len(v)/2)
+}
+
+// FFTFreq returns the frequencies of the bins of an n point FFT of a signal
+// sampled at fs. The frequencies are in FFT order, with the negative frequencies
+// in the second half of the vector.
+func FFTFreq(n int, fs float64) Vector {
+	f := MakeVector(0.0, n)
+	for i := range f {
+		k := i
+		if i >= (n+1)/2 {
+			k = i - n
+		}
+		f[i] = float64(k) * fs / float64(n)
+	}
+	return f
+}
+
+// RFFTFreq returns the non-negative frequencies of the bins of an n point FFT of
+// a real-valued signal sampled at fs. The output vector has length n / 2 + 1.
+func RFFTFreq(n int, fs float64) Vector {
+	f := MakeVector(0.0, n/2+1)
+	for i := range f {
+		f[i] = float64(i) * fs / float64(n)
+	}
+	return f
+}
+
+// rotate returns v with its elements rotated so that v[start] is first.
+func rotate(v Vector, start int) Vector {
+	if len(v) == 0 {
+		return v.Copy()
+	}
+	return append(v.SubVector(start, len(v)), v[:start]...)
+}
+
+// rotateC returns v with its elements rotated so that v[start] is first.
+func rotateC(v VectorComplex, start int) VectorComplex {
+	if len(v) == 0 {
+		return v.Copy()
+	}
+	return append(v.SubVector(start, len(v)), v[:start]...)
+}
@@ -0,0 +1,88 @@
+package gdsp
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFFTShift(t *testing.T) {
+	for _, n := range []int{0, 1, 4, 5} {
+		v := MakeVector(0.0, n)
+		for i := range v {
+			v[i] = float64(i)
+		}
+
+		f := FFTFreq(n, float64(n))
+		s := FFTShift(f)
+		for i := 1; i < len(s); i++ {
+			if s[i] <= s[i-1] {
+				t.Errorf("Shifted frequencies %v should be ascending.", s)
+			}
+		}
+
+		if u := IFFTShift(FFTShift(v)); !u.IsCloseToVector(v, 0.000001) {
+			t.Errorf("%v should be %v.", u, v)
+		}
+
+		vc := v.ToComplex()
+		if u := IFFTShiftC(FFTShiftC(vc)); !u.IsCloseToVectorC(vc, 0.000001) {
+			t.Errorf("%v should be %v.", u, vc)
+		}
+	}
+}
+
+func TestFFTFreq(t *testing.T) {
+	if f := FFTFreq(5, 10.0); !f.IsCloseToVector(Vector{0.0, 2.0, 4.0, -4.0, -2.0}, 0.000001) {
+		t.Errorf("%v is incorrect.", f)
+	}
+
+	if f := FFTFreq(4, 8.0); !f.IsCloseToVector(Vector{0.0, 2.0, -4.0, -2.0}, 0.000001) {
+		t.Errorf("%v is incorrect.", f)
+	}
+
+	if f := RFFTFreq(4, 8.0); !f.IsCloseToVector(Vector{0.0, 2.0, 4.0}, 0.000001) {
+		t.Errorf("%v is incorrect.", f)
+	}
+}
+
+func TestUnwrap(t *testing.T) {
+	phase := MakeVector(0.0, 50)
+	for i := range phase {
+		phase[i] = 0.4 * float64(i)
+	}
+
+	wrapped := MakeVector(0.0, 50)
+	for i := range wrapped {
+		wrapped[i] = math.Atan2(math.Sin(phase[i]), math.Cos(phase[i]))
+	}
+
+	if u := Unwrap(wrapped, math.Pi); !u.IsCloseToVector(phase, 0.000001) {
+		t.Errorf("%v should be %v.", u, phase)
+	}
+}
+
+func TestAngle(t *testing.T) {
+	a := Angle(VectorComplex{1.0, complex(0.0, 2.0), -3.0})
+	if !a.IsCloseToVector(Vector{0.0, math.Pi / 2.0, math.Pi}, 0.000001) {
+		t.Errorf("%v is incorrect.", a)
+	}
+}
+
+func TestDecibels(t *testing.T) {
+	v := Vector{1.0, 10.0, 100.0}
+	if db := Mag2dB(v); !db.IsCloseToVector(Vector{0.0, 20.0, 40.0}, 0.000001) {
+		t.Errorf("%v is incorrect.", db)
+	}
+
+	if db := Pow2dB(v); !db.IsCloseToVector(Vector{0.0, 10.0, 20.0}, 0.000001) {
+		t.Errorf("%v is incorrect.", db)
+	}
+
+	if m := DB2Mag(Mag2dB(v)); !m.IsCloseToVector(v, 0.000001) {
+		t.Errorf("%v should be %v.", m, v)
+	}
+
+	if p := DB2Pow(Pow2dB(v)); !p.IsCloseToVector(v, 0.000001) {
+		t.Errorf("%v should be %v.", p, v)
+	}
+}
@@ -1,8 +1,9 @@
 package gdsp
 
-// Filter performs a 1-dimensional digital filter.
+// Filter performs a 1-dimensional digital filter. The shorter of b and a is
+// padded with trailing zeros.
 func Filter(b Vector, a Vector, x Vector, z Vector) (Vector, Vector) {
-	n := len(a)
+	n := MaxI(len(a), len(b))
 
 	zOut := z.Copy()
 	if len(zOut) < n {
@@ -10,8 +11,8 @@ func Filter(b Vector, a Vector, x Vector, z Vector) (Vector, Vector) {
 	}
 
 	y := MakeVector(0.0, len(x))
-	bn := VSDiv(b, a[0])
-	an := VSDiv(a, a[0])
+	bn := VSDiv(b.PaddedTrailing(0.0, n-len(b)), a[0])
+	an := VSDiv(a.PaddedTrailing(0.0, n-len(a)), a[0])
 
 	for m := 0; m < len(y); m++ {
 		y[m] = bn[0]*x[m] + zOut[0]
@@ -24,9 +25,10 @@ func Filter(b Vector, a Vector, x Vector, z Vector) (Vector, Vector) {
 	return y, zOut[:len(zOut)-1]
 }
 
-// FilterC performs a 1-dimensional digital filter.
+// FilterC performs a 1-dimensional digital filter. The shorter of b and a is
+// padded with trailing zeros.
 func FilterC(b VectorComplex, a VectorComplex, x VectorComplex, z VectorComplex) (VectorComplex, VectorComplex) {
-	n := len(a)
+	n := MaxI(len(a), len(b))
 
 	zOut := z.Copy()
 	if len(zOut) < n {
@@ -34,8 +36,8 @@ func FilterC(b VectorComplex, a VectorComplex, x VectorComplex, z VectorComplex)
 	}
 
 	y := MakeVectorComplex(0.0, len(x))
-	bn := VSDivC(b, a[0])
-	an := VSDivC(a, a[0])
+	bn := VSDivC(b.PaddedTrailing(0.0, n-len(b)), a[0])
+	an := VSDivC(a.PaddedTrailing(0.0, n-len(a)), a[0])
 
 	for m := 0; m < len(y); m++ {
 		y[m] = bn[0]*x[m] + zOut[0]
@@ -60,3 +62,68 @@ func IIR(input Vector, response float64) Vector {
 	}
 	return output
 }
+
+// FilterSteadyState returns the initial conditions for Filter that correspond to
+// the steady state of the filter's step response. Scaling the result by the
+// first input sample reduces the transient at the start of the output.
+func FilterSteadyState(b Vector, a Vector) Vector {
+	n := MaxI(len(a), len(b))
+	if n < 2 {
+		return MakeVector(0.0, 0)
+	}
+
+	bn := VSDiv(b.PaddedTrailing(0.0, n-len(b)), a[0])
+	an := VSDiv(a.PaddedTrailing(0.0, n-len(a)), a[0])
+
+	// Solve (I - A^T) z = b[1:] - a[1:] b[0], where A is the companion matrix of a.
+	m := MakeMatrixComplex(0.0, n-1, n-1)
+	rhs := MakeMatrixComplex(0.0, n-1, 1)
+	for i := 0; i < n-1; i++ {
+		m[i][i] = 1.0
+		m[i][0] += complex(an[i+1], 0.0)
+		if i < n-2 {
+			m[i][i+1] = -1.0
+		}
+		rhs[i][0] = complex(bn[i+1]-an[i+1]*bn[0], 0.0)
+	}
+
+	z := SolveC(m, rhs)
+	if z == nil {
+		return MakeVector(0.0, n-1)
+	}
+
+	zi := MakeVector(0.0, n-1)
+	for i := range zi {
+		zi[i] = real(z[i][0])
+	}
+	return zi
+}
+
+// FiltFilt performs zero-phase digital filtering by filtering the input forward
+// and then backward with Filter. The input is extended at both ends by an odd
+// reflection of 3 * max(len(a), len(b)) samples, and the filter is initialized
+// at its steady state, to reduce edge transients. The effective magnitude
+// response is the square of the filter's.
+func FiltFilt(b Vector, a Vector, x Vector) Vector {
+	if len(x) == 0 {
+		return MakeVector(0.0, 0)
+	}
+
+	padLength := 3 * MaxI(len(a), len(b))
+	if padLength > len(x)-1 {
+		padLength = len(x) - 1
+	}
+
+	ext := MakeVector(0.0, len(x)+2*padLength)
+	for i := 0; i < padLength; i++ {
+		ext[i] = 2.0*x[0] - x[padLength-i]
+		ext[len(ext)-1-i] = 2.0*x[len(x)-1] - x[len(x)-1-padLength+i]
+	}
+	copy(ext[padLength:], x)
+
+	zi := FilterSteadyState(b, a)
+	y, _ := Filter(b, a, ext, VSMul(zi, ext[0]))
+	y = y.Reversed()
+	y, _ = Filter(b, a, y, VSMul(zi, y[0]))
+	return y.Reversed().SubVector(padLength, padLength+len(x))
+}
@@ -57,3 +57,18 @@ func TestFilterHighPass2(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestFilterFIR(t *testing.T) {
+	b := MakeVectorFromArray([]float64{1.0, 2.0, 3.0})
+	a := MakeVectorFromArray([]float64{1.0})
+	x := MakeVectorFromArray([]float64{1.0, 0.0, 0.0, 1.0})
+	y, z := Filter(b, a, x, nil)
+
+	if !y.IsCloseToVector(MakeVectorFromArray([]float64{1.0, 2.0, 3.0, 1.0}), 0.000001) {
+		t.Errorf("%v should be [1 2 3 1].", y)
+	}
+
+	if len(z) != 2 || z[0] != 2.0 || z[1] != 3.0 {
+		t.Errorf("%v should be [2 3].", z)
+	}
+}
@@ -0,0 +1,30 @@
+package gdsp
+
+import (
+	"math"
+)
+
+// FIRLowpass designs a linear-phase lowpass FIR filter with the given number of
+// coefficients using the window method. The cutoff frequency is normalized so
+// that 1 is the Nyquist frequency, and window must have the same length as the
+// filter. The coefficients are scaled to have unit gain at zero frequency.
+func FIRLowpass(length int, cutoff float64, window Vector) Vector {
+	if length < 1 || len(window) != length {
+		return nil
+	}
+
+	h := MakeVector(0.0, length)
+	center := float64(length-1) / 2.0
+	for i := range h {
+		h[i] = cutoff * Sinc(cutoff*(float64(i)-center)) * window[i]
+	}
+	return VSDiv(h, VESum(h))
+}
+
+// Sinc returns the normalized sinc function, sin(pi x) / (pi x), evaluated at x.
+func Sinc(x float64) float64 {
+	if x == 0.0 {
+		return 1.0
+	}
+	return math.Sin(math.Pi*x) / (math.Pi * x)
+}
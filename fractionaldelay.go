@@ -0,0 +1,177 @@
+package gdsp
+
+import (
+	"math"
+)
+
+// LagrangeDelay designs an FIR filter of the given order that delays a signal by
+// delay samples using Lagrange interpolation. The filter has order + 1
+// coefficients and is maximally flat at zero frequency. It is most accurate when
+// delay is close to order / 2.
+func LagrangeDelay(delay float64, order int) Vector {
+	if order < 0 {
+		return nil
+	}
+
+	h := MakeVector(1.0, order+1)
+	for k := range h {
+		for j := 0; j <= order; j++ {
+			if j != k {
+				h[k] *= (delay - float64(j)) / float64(k-j)
+			}
+		}
+	}
+	return h
+}
+
+// ThiranDelay designs an allpass IIR filter of the given order that delays a
+// signal by delay samples and returns its numerator and denominator coefficients
+// for use with Filter. The filter's group delay is maximally flat at zero
+// frequency. The filter is stable when delay is greater than order - 1.
+func ThiranDelay(delay float64, order int) (Vector, Vector) {
+	if order < 1 {
+		return nil, nil
+	}
+
+	a := MakeVector(0.0, order+1)
+	binomial := 1.0
+	for k := range a {
+		a[k] = alternatingSign(k) * binomial
+		for n := 0; n <= order; n++ {
+			a[k] *= (delay - float64(order) + float64(n)) / (delay - float64(order) + float64(k) + float64(n))
+		}
+		binomial *= float64(order-k) / float64(k+1)
+	}
+	return a.Reversed(), a
+}
+
+// SincDelay designs an FIR filter with the given number of coefficients that
+// delays a signal by delay samples by sampling a shifted sinc function and
+// applying the window given by windowType. The coefficients are scaled to have
+// unit gain at zero frequency. The filter is most accurate when delay is close
+// to (length - 1) / 2.
+func SincDelay(delay float64, length int, windowType WindowType) Vector {
+	w := MakeWindow(windowType, length)
+	if w == nil || length < 1 {
+		return nil
+	}
+
+	h := MakeVector(0.0, length)
+	for n := range h {
+		h[n] = Sinc(float64(n)-delay) * w[n]
+	}
+	return VSDiv(h, VESum(h))
+}
+
+// FarrowDelay types are variable fractional delay lines that use the Farrow
+// structure. Each output sample is the input delayed by a per-sample delay,
+// computed by evaluating a polynomial in the fractional delay whose coefficients
+// are the outputs of fixed FIR subfilters. The subfilters implement Lagrange
+// interpolation, so a constant delay matches filtering with LagrangeDelay.
+type FarrowDelay struct {
+	order        int
+	maxDelay     float64
+	coefficients Matrix
+
+	history VectorComplex
+	index   int
+}
+
+// MakeFarrowDelay creates a Farrow delay line with Lagrange subfilters of the
+// given order that supports delays from 0 to maxDelay samples. nil is returned if
+// order is less than 1 or maxDelay is negative.
+func MakeFarrowDelay(maxDelay float64, order int) *FarrowDelay {
+	if order < 1 || maxDelay < 0.0 {
+		return nil
+	}
+
+	f := &FarrowDelay{
+		order:        order,
+		maxDelay:     maxDelay,
+		coefficients: MakeMatrix(0.0, order+1, order+1),
+	}
+
+	// The Lagrange coefficient h[k](d) is a polynomial of degree order in d with
+	// roots at the integers other than k. Row m holds the coefficients of d^m.
+	for k := 0; k <= order; k++ {
+		roots := MakeVectorComplex(0.0, 0)
+		scale := 1.0
+		for j := 0; j <= order; j++ {
+			if j != k {
+				roots = append(roots, complex(float64(j), 0.0))
+				scale *= float64(k - j)
+			}
+		}
+
+		p := Poly(roots).Real()
+		for m := 0; m <= order; m++ {
+			f.coefficients[m][k] = p[order-m] / scale
+		}
+	}
+
+	f.Reset()
+	return f
+}
+
+// Reset clears the delay line.
+func (f *FarrowDelay) Reset() {
+	f.history = MakeVectorComplex(0.0, int(math.Ceil(f.maxDelay))+f.order+1)
+	f.index = 0
+}
+
+// Update adds the real-valued sample x to the delay line and returns the input
+// delayed by delay samples. The delay is clamped to the range [0, maxDelay].
+func (f *FarrowDelay) Update(x float64, delay float64) float64 {
+	return real(f.UpdateC(complex(x, 0.0), delay))
+}
+
+// UpdateC adds the complex-valued sample x to the delay line and returns the
+// input delayed by delay samples. The delay is clamped to the range
+// [0, maxDelay].
+func (f *FarrowDelay) UpdateC(x complex128, delay float64) complex128 {
+	f.index = (f.index + 1) % len(f.history)
+	f.history[f.index] = x
+
+	delay = math.Max(0.0, math.Min(delay, f.maxDelay))
+
+	// Split the delay in to an integer offset and a fractional part in the
+	// middle of the interpolator's range, where it is most accurate.
+	offset := MaxI(int(math.Floor(delay))-(f.order-1)/2, 0)
+	d := complex(delay-float64(offset), 0.0)
+
+	y := complex(0.0, 0.0)
+	for m := f.order; m >= 0; m-- {
+		v := complex(0.0, 0.0)
+		for k, c := range f.coefficients[m] {
+			v += complex(c, 0.0) * f.history[(f.index-offset-k+2*len(f.history))%len(f.history)]
+		}
+		y = y*d + v
+	}
+	return y
+}
+
+// Process delays each sample of the real-valued input vector by the
+// corresponding element of delays and returns the result. nil is returned if the
+// vectors have different lengths.
+func (f *FarrowDelay) Process(input Vector, delays Vector) Vector {
+	output := f.ProcessC(input.ToComplex(), delays)
+	if output == nil {
+		return nil
+	}
+	return output.Real()
+}
+
+// ProcessC delays each sample of the complex-valued input vector by the
+// corresponding element of delays and returns the result. nil is returned if the
+// vectors have different lengths.
+func (f *FarrowDelay) ProcessC(input VectorComplex, delays Vector) VectorComplex {
+	if len(input) != len(delays) {
+		return nil
+	}
+
+	output := MakeVectorComplex(0.0, len(input))
+	for i, x := range input {
+		output[i] = f.UpdateC(x, delays[i])
+	}
+	return output
+}
@@ -0,0 +1,78 @@
+package gdsp
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// checkDelayed tests that y is the sinusoid with the given period delayed by
+// delay samples, ignoring the first skip samples.
+func checkDelayed(t *testing.T, name string, y Vector, period float64, delay float64, skip int, tolerance float64) {
+	for i := skip; i < len(y); i++ {
+		expected := math.Sin(2.0 * math.Pi * (float64(i) - delay) / period)
+		if !IsClose(y[i], expected, tolerance) {
+			t.Errorf("%s: %f at %d should be %f.", name, y[i], i, expected)
+			return
+		}
+	}
+}
+
+func TestLagrangeDelay(t *testing.T) {
+	if h := LagrangeDelay(2.0, 4); !h.IsCloseToVector(Vector{0.0, 0.0, 1.0, 0.0, 0.0}, 0.000001) {
+		t.Errorf("%v should be a unit impulse at 2.", h)
+	}
+
+	x := sine(200, 40.0)
+	y, _ := Filter(LagrangeDelay(1.7, 3), Vector{1.0}, x, nil)
+	checkDelayed(t, "Lagrange", y, 40.0, 1.7, 10, 0.001)
+}
+
+func TestThiranDelay(t *testing.T) {
+	b, a := ThiranDelay(3.3, 3)
+	for _, f := range []float64{0.1, 0.4, 0.7} {
+		if g := cmplx.Abs(frequencyResponse(b, a, f)); !IsClose(g, 1.0, 0.000001) {
+			t.Errorf("Gain %f at %f should be 1.", g, f)
+		}
+	}
+
+	x := sine(400, 40.0)
+	y, _ := Filter(b, a, x, nil)
+	checkDelayed(t, "Thiran", y, 40.0, 3.3, 100, 0.001)
+}
+
+func TestSincDelay(t *testing.T) {
+	x := sine(300, 40.0)
+	y, _ := Filter(SincDelay(15.4, 32, WindowTypeHamming), Vector{1.0}, x, nil)
+	checkDelayed(t, "Sinc", y, 40.0, 15.4, 40, 0.005)
+}
+
+func TestFarrowDelay(t *testing.T) {
+	x := sine(300, 40.0)
+
+	f := MakeFarrowDelay(10.0, 3)
+	y := f.Process(x, MakeVector(1.3, len(x)))
+	expected, _ := Filter(LagrangeDelay(1.3, 3), Vector{1.0}, x, nil)
+	if !y.IsCloseToVector(expected, 0.000001) {
+		t.Errorf("A constant delay should match LagrangeDelay.")
+	}
+
+	f.Reset()
+	delays := MakeVector(0.0, len(x))
+	for i := range delays {
+		delays[i] = 5.0 + 4.0*math.Sin(2.0*math.Pi*float64(i)/150.0)
+	}
+
+	y = f.Process(x, delays)
+	for i := 20; i < len(y); i++ {
+		expected := math.Sin(2.0 * math.Pi * (float64(i) - delays[i]) / 40.0)
+		if !IsClose(y[i], expected, 0.001) {
+			t.Errorf("%f at %d should be %f.", y[i], i, expected)
+			break
+		}
+	}
+
+	if y := f.Process(x, Vector{1.0}); y != nil {
+		t.Errorf("Mismatched delays should return nil.")
+	}
+}
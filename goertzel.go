@@ -0,0 +1,142 @@
+package gdsp
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Goertzel evaluates the discrete Fourier transform of the real-valued input
+// vector at the given bins using the Goertzel algorithm. Bins are measured in
+// units of fs / len(input) and need not be integers, so the value at bin k is
+//
+// X(k) = sum(input[n] * exp(-2 * pi * i * k * n / len(input)))
+//
+// which is equal to FFT(input)[k] for integer k.
+func Goertzel(input Vector, bins Vector) VectorComplex {
+	return GoertzelC(input.ToComplex(), bins)
+}
+
+// GoertzelC evaluates the discrete Fourier transform of the complex-valued input
+// vector at the given bins using the Goertzel algorithm. Bins are measured in
+// units of fs / len(input) and need not be integers.
+func GoertzelC(input VectorComplex, bins Vector) VectorComplex {
+	N := len(input)
+	output := MakeVectorComplex(0.0, len(bins))
+	if N == 0 {
+		return output
+	}
+
+	for i, k := range bins {
+		w := 2.0 * math.Pi * k / float64(N)
+		coeff := complex(2.0*math.Cos(w), 0.0)
+
+		s1 := complex(0.0, 0.0)
+		s2 := complex(0.0, 0.0)
+		for _, x := range input {
+			s0 := x + coeff*s1 - s2
+			s2 = s1
+			s1 = s0
+		}
+
+		y := s1 - cmplx.Exp(complex(0.0, -w))*s2
+		output[i] = y * cmplx.Exp(complex(0.0, -w*float64(N-1)))
+	}
+	return output
+}
+
+// SlidingDFT types track selected bins of the discrete Fourier transform of the
+// most recent samples of a signal, updated one sample at a time.
+type SlidingDFT struct {
+	length  int
+	bins    Vector
+	damping float64
+
+	poles   VectorComplex
+	combs   VectorComplex
+	phases  VectorComplex
+	states  VectorComplex
+	history VectorComplex
+	index   int
+}
+
+// MakeSlidingDFT creates a sliding DFT over windows of length samples that
+// tracks the given bins. Bins are measured in units of fs / length and need not
+// be integers.
+//
+// The damping factor, r, should be in the range (0, 1]. Values slightly less
+// than one, such as 0.99999, move the recursion's poles inside the unit circle so
+// that round-off errors decay instead of accumulating, at the cost of weighting
+// older samples in the window by up to r^(length - 1).
+//
+// nil is returned if length is less than one.
+func MakeSlidingDFT(length int, bins Vector, damping float64) *SlidingDFT {
+	if length < 1 {
+		return nil
+	}
+
+	s := &SlidingDFT{
+		length:  length,
+		bins:    bins.Copy(),
+		damping: damping,
+		poles:   MakeVectorComplex(0.0, len(bins)),
+		combs:   MakeVectorComplex(0.0, len(bins)),
+		phases:  MakeVectorComplex(0.0, len(bins)),
+	}
+
+	for i, k := range bins {
+		w := 2.0 * math.Pi * k / float64(length)
+		s.poles[i] = cmplx.Rect(damping, w)
+		s.combs[i] = cmplx.Rect(math.Pow(damping, float64(length)), w*float64(length))
+		s.phases[i] = cmplx.Exp(complex(0.0, -w*float64(length-1)))
+	}
+
+	s.Reset()
+	return s
+}
+
+// Reset clears the sliding DFT's window.
+func (s *SlidingDFT) Reset() {
+	s.states = MakeVectorComplex(0.0, len(s.bins))
+	s.history = MakeVectorComplex(0.0, s.length)
+	s.index = 0
+}
+
+// Update adds the real-valued sample x to the window, removes the oldest sample
+// and returns the updated bins.
+func (s *SlidingDFT) Update(x float64) VectorComplex {
+	return s.UpdateC(complex(x, 0.0))
+}
+
+// UpdateC adds the complex-valued sample x to the window, removes the oldest
+// sample and returns the updated bins.
+func (s *SlidingDFT) UpdateC(x complex128) VectorComplex {
+	oldest := s.history[s.index]
+	s.history[s.index] = x
+	s.index = (s.index + 1) % s.length
+
+	for i := range s.states {
+		s.states[i] = x + s.poles[i]*s.states[i] - s.combs[i]*oldest
+	}
+	return s.Bins()
+}
+
+// Process updates the sliding DFT with each sample of the real-valued input
+// vector and returns the bins after the last sample.
+func (s *SlidingDFT) Process(input Vector) VectorComplex {
+	return s.ProcessC(input.ToComplex())
+}
+
+// ProcessC updates the sliding DFT with each sample of the complex-valued input
+// vector and returns the bins after the last sample.
+func (s *SlidingDFT) ProcessC(input VectorComplex) VectorComplex {
+	for _, x := range input {
+		s.UpdateC(x)
+	}
+	return s.Bins()
+}
+
+// Bins returns the current value of each tracked bin. With no damping, these are
+// the values that Goertzel would return for the window's samples.
+func (s *SlidingDFT) Bins() VectorComplex {
+	return VMulEC(s.phases, s.states)
+}
@@ -0,0 +1,68 @@
+package gdsp
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGoertzel(t *testing.T) {
+	v := MakeVectorComplexFromArray([]complex128{1.0, 2.0, complex(3.0, 1.0), 4.0, 4.0, 3.0, 2.0, complex(1.0, -2.0)})
+	fft := FFT(v)
+	bins := Vector{0.0, 1.0, 3.0, 7.0}
+
+	g := GoertzelC(v, bins)
+	for i, k := range bins {
+		if !IsCloseC(g[i], fft[int(k)], 0.000001) {
+			t.Errorf("%v at bin %f should be %v.", g[i], k, fft[int(k)])
+		}
+	}
+}
+
+func TestGoertzelFractional(t *testing.T) {
+	x := MakeVector(0.0, 100)
+	for i := range x {
+		x[i] = math.Cos(2.0 * math.Pi * 12.5 * float64(i) / 100.0)
+	}
+
+	g := Goertzel(x, Vector{12.5, 30.0})
+	if math.Abs(VMagC(g)[0]-50.0) > 1.0 {
+		t.Errorf("Magnitude %f at bin 12.5 should be close to 50.", VMagC(g)[0])
+	}
+
+	if VMagC(g)[1] > 2.0 {
+		t.Errorf("Magnitude %f at bin 30 should be small.", VMagC(g)[1])
+	}
+}
+
+func TestSlidingDFT(t *testing.T) {
+	x := MakeVector(0.0, 200)
+	for i := range x {
+		x[i] = math.Sin(0.3*float64(i)) + 0.1*float64(i%7)
+	}
+
+	bins := Vector{2.0, 5.5}
+	s := MakeSlidingDFT(32, bins, 1.0)
+	for i := range x {
+		b := s.Update(x[i])
+		if i >= 31 {
+			g := Goertzel(x.SubVector(i-31, i+1), bins)
+			if !b.IsCloseToVectorC(g, 0.000001) {
+				t.Fatalf("%v at sample %d should be %v.", b, i, g)
+			}
+		}
+	}
+
+	if s := MakeSlidingDFT(0, bins, 1.0); s != nil {
+		t.Errorf("A length less than one should return nil.")
+	}
+}
+
+func TestSlidingDFTDamped(t *testing.T) {
+	x := MakeVectorComplex(complex(1.0, 1.0), 1000)
+	s := MakeSlidingDFT(16, Vector{0.0}, 0.99999)
+	b := s.ProcessC(x)
+
+	if !IsCloseC(b[0], complex(16.0, 16.0), 0.01) {
+		t.Errorf("%v should be close to %v.", b[0], complex(16.0, 16.0))
+	}
+}
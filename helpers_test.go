@@ -0,0 +1,42 @@
+package gdsp
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// noise returns n samples of deterministic pseudo-random noise, roughly uniform
+// in [-0.5, 0.5), for the given seed.
+func noise(n int, seed float64) Vector {
+	v := MakeVector(0.0, n)
+	for i := range v {
+		x := math.Sin(float64(i)*12.9898+seed) * 43758.5453
+		v[i] = x - math.Floor(x) - 0.5
+	}
+	return v
+}
+
+// sine returns n samples of a unit sine wave with the given period in samples.
+func sine(n int, period float64) Vector {
+	x := MakeVector(0.0, n)
+	for i := range x {
+		x[i] = math.Sin(2.0 * math.Pi * float64(i) / period)
+	}
+	return x
+}
+
+// frequencyResponse evaluates the filter b / a at the frequency f, normalized so
+// that 1 is the Nyquist frequency.
+func frequencyResponse(b Vector, a Vector, f float64) complex128 {
+	z := cmplx.Exp(complex(0.0, -math.Pi*f))
+	return PolyvalC(b.Reversed().ToComplex(), z) / PolyvalC(a.Reversed().ToComplex(), z)
+}
+
+// checkFrequencies reports an error if the frequencies f estimated by name are
+// not within tolerance of expected.
+func checkFrequencies(t *testing.T, name string, f Vector, expected Vector, tolerance float64) {
+	if !f.IsCloseToVector(expected, tolerance) {
+		t.Errorf("%s: %v should be %v.", name, f, expected)
+	}
+}
@@ -0,0 +1,83 @@
+package gdsp
+
+import (
+	"math"
+)
+
+// Hilbert computes the analytic signal of the real-valued input vector using an
+// FFT. The real part of the result is the input and the imaginary part is its
+// Hilbert transform.
+func Hilbert(input Vector) VectorComplex {
+	N := len(input)
+	if N == 0 {
+		return MakeVectorComplex(0.0, 0)
+	}
+
+	h := MakeVectorComplex(0.0, N)
+	h[0] = 1.0
+	if N%2 == 0 {
+		h[N/2] = 1.0
+		for i := 1; i < N/2; i++ {
+			h[i] = 2.0
+		}
+	} else {
+		for i := 1; i <= (N-1)/2; i++ {
+			h[i] = 2.0
+		}
+	}
+
+	return IFFT(VMulEC(FFT(input.ToComplex()), h))
+}
+
+// Envelope returns the amplitude envelope of the real-valued input vector, which
+// is the magnitude of its analytic signal.
+func Envelope(input Vector) Vector {
+	return VMagC(Hilbert(input))
+}
+
+// InstantaneousPhase returns the unwrapped phase, in radians, of the analytic
+// signal of the real-valued input vector.
+func InstantaneousPhase(input Vector) Vector {
+	return Unwrap(Angle(Hilbert(input)), math.Pi)
+}
+
+// InstantaneousFrequency returns the instantaneous frequency of the real-valued
+// input vector, sampled at fs, from the differences of its instantaneous phase.
+// The output vector has length len(input) - 1.
+func InstantaneousFrequency(input Vector, fs float64) Vector {
+	phase := InstantaneousPhase(input)
+	if len(phase) < 2 {
+		return MakeVector(0.0, 0)
+	}
+
+	f := MakeVector(0.0, len(phase)-1)
+	for i := range f {
+		f[i] = (phase[i+1] - phase[i]) * fs / (2.0 * math.Pi)
+	}
+	return f
+}
+
+// HilbertFIR designs a type III FIR Hilbert transformer of the given odd length
+// using the window method with the window given by windowType. The filter
+// coefficients can be used with Filter to compute the Hilbert transform of a
+// stream of samples, delayed by (length - 1) / 2 samples.
+func HilbertFIR(length int, windowType WindowType) Vector {
+	if length%2 == 0 || length < 3 {
+		return nil
+	}
+
+	w := MakeWindow(windowType, length)
+	if w == nil {
+		return nil
+	}
+
+	h := MakeVector(0.0, length)
+	center := (length - 1) / 2
+	for i := range h {
+		m := i - center
+		if m%2 != 0 {
+			h[i] = 2.0 / (math.Pi * float64(m)) * w[i]
+		}
+	}
+	return h
+}
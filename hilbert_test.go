@@ -0,0 +1,79 @@
+package gdsp
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHilbert(t *testing.T) {
+	for _, N := range []int{64, 65} {
+		x := MakeVector(0.0, N)
+		for i := range x {
+			x[i] = math.Cos(2.0 * math.Pi * 4.0 * float64(i) / float64(N))
+		}
+
+		a := Hilbert(x)
+		for i := range a {
+			s := math.Sin(2.0 * math.Pi * 4.0 * float64(i) / float64(N))
+			if !IsClose(real(a[i]), x[i], 0.000001) || !IsClose(imag(a[i]), s, 0.000001) {
+				t.Errorf("%v at %d should be %f + %fi.", a[i], i, x[i], s)
+			}
+		}
+	}
+}
+
+func TestEnvelope(t *testing.T) {
+	x := MakeVector(0.0, 256)
+	for i := range x {
+		n := float64(i)
+		x[i] = (1.0 + 0.5*math.Cos(2.0*math.Pi*2.0*n/256.0)) * math.Cos(2.0*math.Pi*40.0*n/256.0)
+	}
+
+	e := Envelope(x)
+	for i := range e {
+		expected := 1.0 + 0.5*math.Cos(2.0*math.Pi*2.0*float64(i)/256.0)
+		if !IsClose(e[i], expected, 0.000001) {
+			t.Errorf("%f at %d should be %f.", e[i], i, expected)
+		}
+	}
+}
+
+func TestInstantaneousFrequency(t *testing.T) {
+	fs := 256.0
+	x := MakeVector(0.0, 256)
+	for i := range x {
+		x[i] = math.Sin(2.0 * math.Pi * 20.0 * float64(i) / fs)
+	}
+
+	phase := InstantaneousPhase(x)
+	if phase[255] < 2.0*math.Pi*19.0 {
+		t.Errorf("Phase %f should be unwrapped.", phase[255])
+	}
+
+	f := InstantaneousFrequency(x, fs)
+	if len(f) != 255 {
+		t.Fatalf("There should be 255 frequencies (%d).", len(f))
+	}
+
+	for i := range f {
+		if !IsClose(f[i], 20.0, 0.000001) {
+			t.Errorf("%f at %d should be 20.0.", f[i], i)
+		}
+	}
+}
+
+func TestHilbertFIR(t *testing.T) {
+	h := HilbertFIR(63, WindowTypeHamming)
+	x := MakeVector(0.0, 400)
+	for i := range x {
+		x[i] = math.Cos(0.5 * float64(i))
+	}
+
+	y, _ := Filter(h, MakeVector(1.0, 1), x, nil)
+	for i := 100; i < len(y); i++ {
+		expected := math.Sin(0.5 * float64(i-31))
+		if !IsClose(y[i], expected, 0.01) {
+			t.Errorf("%f at %d should be %f.", y[i], i, expected)
+		}
+	}
+}
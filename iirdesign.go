@@ -0,0 +1,42 @@
+package gdsp
+
+import (
+	"math"
+)
+
+// Chebyshev1 designs a digital lowpass Chebyshev type I filter of the given
+// order and returns its numerator and denominator coefficients for use with
+// Filter. The filter has ripple decibels of equiripple in the passband and its
+// gain first drops below -ripple decibels at cutoff, normalized so that 1 is the
+// Nyquist frequency.
+//
+// The analog prototype is mapped to the z-plane with the bilinear transform
+// after prewarping the cutoff frequency.
+func Chebyshev1(order int, ripple float64, cutoff float64) (Vector, Vector) {
+	if order < 1 || ripple <= 0.0 || cutoff <= 0.0 || cutoff >= 1.0 {
+		return nil, nil
+	}
+
+	eps := math.Sqrt(math.Pow(10.0, ripple/10.0) - 1.0)
+	mu := math.Asinh(1.0/eps) / float64(order)
+	warped := 4.0 * math.Tan(math.Pi*cutoff/2.0)
+
+	poles := MakeVectorComplex(0.0, order)
+	gain := complex(1.0, 0.0)
+	for k := range poles {
+		theta := math.Pi * float64(2*k+1) / float64(2*order)
+		p := complex(-math.Sinh(mu)*math.Sin(theta), math.Cosh(mu)*math.Cos(theta)) * complex(warped, 0.0)
+		poles[k] = (4.0 + p) / (4.0 - p)
+		gain *= -p / (4.0 - p)
+	}
+	if order%2 == 0 {
+		gain /= complex(math.Sqrt(1.0+eps*eps), 0.0)
+	}
+
+	b := MakeVector(0.0, order+1)
+	b[0] = real(gain)
+	for i := 1; i <= order; i++ {
+		b[i] = b[i-1] * float64(order-i+1) / float64(i)
+	}
+	return b, Poly(poles).Real()
+}
@@ -0,0 +1,321 @@
+package gdsp
+
+import (
+	"math"
+	"sort"
+)
+
+// InterpolationMethod values represent the method used by Interp1 to
+// interpolate between samples.
+type InterpolationMethod int
+
+// Types of interpolation methods.
+const (
+	// InterpolationMethodLinear connects adjacent samples with straight lines.
+	InterpolationMethodLinear InterpolationMethod = iota + 1
+
+	// InterpolationMethodPCHIP uses a piecewise cubic Hermite interpolating
+	// polynomial with Fritsch-Carlson slopes, which preserves the monotonicity of
+	// the samples and does not overshoot.
+	InterpolationMethodPCHIP
+
+	// InterpolationMethodSplineNatural uses a cubic spline with zero second
+	// derivatives at the end points.
+	InterpolationMethodSplineNatural
+
+	// InterpolationMethodSplineNotAKnot uses a cubic spline whose first two and
+	// last two pieces are the same cubic polynomial.
+	InterpolationMethodSplineNotAKnot
+
+	// InterpolationMethodAkima uses Akima's piecewise cubic interpolation, whose
+	// slopes are weighted averages of the neighbouring secant slopes and which
+	// reduces the oscillation of splines near outliers.
+	InterpolationMethodAkima
+)
+
+// ExtrapolationPolicy values represent how Interp1 evaluates query points
+// outside the range of the samples.
+type ExtrapolationPolicy int
+
+// Types of extrapolation policies.
+const (
+	// ExtrapolationPolicyNaN returns NaN outside the range of the samples.
+	ExtrapolationPolicyNaN ExtrapolationPolicy = iota + 1
+
+	// ExtrapolationPolicyNearest returns the value of the nearest end sample.
+	ExtrapolationPolicyNearest
+
+	// ExtrapolationPolicyExtend evaluates the first or last polynomial piece.
+	ExtrapolationPolicyExtend
+)
+
+// Interp1 interpolates the samples y, taken at the strictly increasing points x,
+// at the query points xq using the given method. Query points outside the range
+// of x are handled with the given extrapolation policy. nil is returned if x and
+// y have different lengths, contain fewer than two samples or if x is not
+// strictly increasing.
+//
+// Cubic methods fall back to lower degrees with too few samples: with two
+// samples every method is linear, and with three samples the not-a-knot spline
+// is the parabola through the samples. For a spline with given end slopes, see
+// Interp1Clamped.
+func Interp1(x Vector, y Vector, xq Vector, method InterpolationMethod, extrapolation ExtrapolationPolicy) Vector {
+	if !isInterpolationGrid(x, y) || !isExtrapolationPolicy(extrapolation) {
+		return nil
+	}
+
+	var slopes Vector
+	switch method {
+	case InterpolationMethodLinear:
+	case InterpolationMethodPCHIP:
+		slopes = pchipSlopes(x, y)
+	case InterpolationMethodSplineNatural, InterpolationMethodSplineNotAKnot:
+		slopes = splineSlopes(x, y, method)
+	case InterpolationMethodAkima:
+		slopes = akimaSlopes(x, y)
+	default:
+		return nil
+	}
+
+	return hermiteInterpolate(x, y, slopes, xq, extrapolation)
+}
+
+// Interp1Clamped interpolates the samples y, taken at the strictly increasing
+// points x, at the query points xq using a clamped cubic spline, whose first
+// derivatives at x[0] and x[len(x)-1] are startSlope and endSlope. Query points
+// outside the range of x are handled with the given extrapolation policy. nil is
+// returned if x and y have different lengths, contain fewer than two samples, if
+// x is not strictly increasing or if the extrapolation policy is not valid.
+//
+// With two samples the spline is the cubic Hermite polynomial through the
+// samples with the given end slopes.
+func Interp1Clamped(x Vector, y Vector, xq Vector, startSlope float64, endSlope float64, extrapolation ExtrapolationPolicy) Vector {
+	if !isInterpolationGrid(x, y) || !isExtrapolationPolicy(extrapolation) {
+		return nil
+	}
+
+	return hermiteInterpolate(x, y, clampedSplineSlopes(x, y, startSlope, endSlope), xq, extrapolation)
+}
+
+// isInterpolationGrid returns whether x and y are valid samples for
+// interpolation.
+func isInterpolationGrid(x Vector, y Vector) bool {
+	if len(x) < 2 || len(y) != len(x) {
+		return false
+	}
+
+	for i := 1; i < len(x); i++ {
+		if x[i] <= x[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+// isExtrapolationPolicy returns whether p is a valid extrapolation policy.
+func isExtrapolationPolicy(p ExtrapolationPolicy) bool {
+	switch p {
+	case ExtrapolationPolicyNaN, ExtrapolationPolicyNearest, ExtrapolationPolicyExtend:
+		return true
+	}
+	return false
+}
+
+// hermiteInterpolate evaluates the piecewise cubic Hermite polynomial through the
+// samples with the given slopes at the query points xq, or the piecewise linear
+// interpolant if slopes is nil.
+func hermiteInterpolate(x Vector, y Vector, slopes Vector, xq Vector, extrapolation ExtrapolationPolicy) Vector {
+	n := len(x)
+	output := MakeVector(0.0, len(xq))
+	for j, v := range xq {
+		if v < x[0] || v > x[n-1] {
+			switch extrapolation {
+			case ExtrapolationPolicyNaN:
+				output[j] = math.NaN()
+				continue
+			case ExtrapolationPolicyNearest:
+				if v < x[0] {
+					output[j] = y[0]
+				} else {
+					output[j] = y[n-1]
+				}
+				continue
+			}
+		}
+
+		i := MinI(MaxI(sort.SearchFloat64s(x, v)-1, 0), n-2)
+		h := x[i+1] - x[i]
+		t := (v - x[i]) / h
+
+		if slopes == nil {
+			output[j] = y[i] + t*(y[i+1]-y[i])
+			continue
+		}
+
+		h00 := (1.0 + 2.0*t) * (1.0 - t) * (1.0 - t)
+		h10 := t * (1.0 - t) * (1.0 - t)
+		h01 := t * t * (3.0 - 2.0*t)
+		h11 := t * t * (t - 1.0)
+		output[j] = h00*y[i] + h10*h*slopes[i] + h01*y[i+1] + h11*h*slopes[i+1]
+	}
+	return output
+}
+
+// secantSlopes returns the slopes of the lines between adjacent samples.
+func secantSlopes(x Vector, y Vector) Vector {
+	d := MakeVector(0.0, len(x)-1)
+	for i := range d {
+		d[i] = (y[i+1] - y[i]) / (x[i+1] - x[i])
+	}
+	return d
+}
+
+// secantWidths returns the widths of the intervals between adjacent samples.
+func secantWidths(x Vector) Vector {
+	h := MakeVector(0.0, len(x)-1)
+	for i := range h {
+		h[i] = x[i+1] - x[i]
+	}
+	return h
+}
+
+// pchipSlopes returns the Fritsch-Carlson slopes at each sample, which are the
+// weighted harmonic means of the adjacent secant slopes, or zero at local
+// extrema.
+func pchipSlopes(x Vector, y Vector) Vector {
+	n := len(x)
+	d := secantSlopes(x, y)
+	if n == 2 {
+		return Vector{d[0], d[0]}
+	}
+
+	m := MakeVector(0.0, n)
+	for k := 1; k < n-1; k++ {
+		if d[k-1]*d[k] <= 0.0 {
+			continue
+		}
+
+		h0 := x[k] - x[k-1]
+		h1 := x[k+1] - x[k]
+		w1 := 2.0*h1 + h0
+		w2 := h1 + 2.0*h0
+		m[k] = (w1 + w2) / (w1/d[k-1] + w2/d[k])
+	}
+
+	m[0] = pchipEndSlope(x[1]-x[0], x[2]-x[1], d[0], d[1])
+	m[n-1] = pchipEndSlope(x[n-1]-x[n-2], x[n-2]-x[n-3], d[n-2], d[n-3])
+	return m
+}
+
+// pchipEndSlope returns the slope at an end point from a three point estimate,
+// limited so that the interpolant remains shape preserving. h0 and d0 belong to
+// the interval at the end point and h1 and d1 to its neighbour.
+func pchipEndSlope(h0 float64, h1 float64, d0 float64, d1 float64) float64 {
+	m := ((2.0*h0+h1)*d0 - h0*d1) / (h0 + h1)
+	if m*d0 <= 0.0 {
+		return 0.0
+	}
+
+	if d0*d1 < 0.0 && math.Abs(m) > math.Abs(3.0*d0) {
+		return 3.0 * d0
+	}
+	return m
+}
+
+// splineSlopes returns the first derivatives at each sample of the cubic spline
+// with the end conditions given by method.
+func splineSlopes(x Vector, y Vector, method InterpolationMethod) Vector {
+	n := len(x)
+	d := secantSlopes(x, y)
+	if n == 2 {
+		return Vector{d[0], d[0]}
+	}
+
+	h := secantWidths(x)
+	if n == 3 && method == InterpolationMethodSplineNotAKnot {
+		// The parabola through the three samples.
+		c := (d[1] - d[0]) / (x[2] - x[0])
+		return Vector{d[0] - c*h[0], d[0] + c*h[0], d[1] + c*h[1]}
+	}
+
+	lower, diag, upper, b := splineSystem(h, d)
+	switch method {
+	case InterpolationMethodSplineNatural:
+		diag[0], upper[0], b[0] = 2.0, 1.0, 3.0*d[0]
+		lower[n-1], diag[n-1], b[n-1] = 1.0, 2.0, 3.0*d[n-2]
+	case InterpolationMethodSplineNotAKnot:
+		s := x[2] - x[0]
+		diag[0], upper[0] = h[1], s
+		b[0] = ((h[0]+2.0*s)*h[1]*d[0] + h[0]*h[0]*d[1]) / s
+
+		s = x[n-1] - x[n-3]
+		lower[n-1], diag[n-1] = s, h[n-3]
+		b[n-1] = (h[n-2]*h[n-2]*d[n-3] + (2.0*s+h[n-2])*h[n-3]*d[n-2]) / s
+	}
+
+	return tridiagonalSolve(lower, diag, upper, b)
+}
+
+// clampedSplineSlopes returns the first derivatives at each sample of the cubic
+// spline whose first derivatives at its end points are startSlope and endSlope.
+func clampedSplineSlopes(x Vector, y Vector, startSlope float64, endSlope float64) Vector {
+	n := len(x)
+	if n == 2 {
+		return Vector{startSlope, endSlope}
+	}
+
+	lower, diag, upper, b := splineSystem(secantWidths(x), secantSlopes(x, y))
+	diag[0], b[0] = 1.0, startSlope
+	diag[n-1], b[n-1] = 1.0, endSlope
+	return tridiagonalSolve(lower, diag, upper, b)
+}
+
+// splineSystem returns the tridiagonal system for the first derivatives of a
+// cubic spline with interval widths h and secant slopes d. The rows of the
+// interior samples make the second derivatives continuous, and the rows of the
+// end points are left zero for the end conditions.
+func splineSystem(h Vector, d Vector) (Vector, Vector, Vector, Vector) {
+	n := len(h) + 1
+	lower := MakeVector(0.0, n)
+	diag := MakeVector(0.0, n)
+	upper := MakeVector(0.0, n)
+	b := MakeVector(0.0, n)
+	for i := 1; i < n-1; i++ {
+		lower[i] = h[i]
+		diag[i] = 2.0 * (h[i-1] + h[i])
+		upper[i] = h[i-1]
+		b[i] = 3.0 * (h[i]*d[i-1] + h[i-1]*d[i])
+	}
+	return lower, diag, upper, b
+}
+
+// akimaSlopes returns Akima's slopes at each sample, which weight the adjacent
+// secant slopes by the differences of the secant slopes on the opposite sides.
+// The secant slopes are extended by two intervals at each end by linear
+// extrapolation.
+func akimaSlopes(x Vector, y Vector) Vector {
+	n := len(x)
+	d := secantSlopes(x, y)
+	if n == 2 {
+		return Vector{d[0], d[0]}
+	}
+
+	e := MakeVector(0.0, n+3)
+	copy(e[2:], d)
+	e[1] = 2.0*e[2] - e[3]
+	e[0] = 2.0*e[1] - e[2]
+	e[n+1] = 2.0*e[n] - e[n-1]
+	e[n+2] = 2.0*e[n+1] - e[n]
+
+	m := MakeVector(0.0, n)
+	for i := range m {
+		w1 := math.Abs(e[i+3] - e[i+2])
+		w2 := math.Abs(e[i+1] - e[i])
+		if w1+w2 == 0.0 {
+			m[i] = (e[i+1] + e[i+2]) / 2.0
+		} else {
+			m[i] = (w1*e[i+1] + w2*e[i+2]) / (w1 + w2)
+		}
+	}
+	return m
+}
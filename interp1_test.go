@@ -0,0 +1,171 @@
+package gdsp
+
+import (
+	"math"
+	"testing"
+)
+
+var interpolationMethods = []InterpolationMethod{
+	InterpolationMethodLinear,
+	InterpolationMethodPCHIP,
+	InterpolationMethodSplineNatural,
+	InterpolationMethodSplineNotAKnot,
+	InterpolationMethodAkima,
+}
+
+func TestInterp1Samples(t *testing.T) {
+	x := Vector{0.0, 0.5, 1.7, 2.0, 3.1, 4.0}
+	y := Vector{1.0, -2.0, 0.5, 0.7, 3.0, -1.0}
+
+	for _, method := range interpolationMethods {
+		if yq := Interp1(x, y, x, method, ExtrapolationPolicyNaN); !yq.IsCloseToVector(y, 0.000001) {
+			t.Errorf("Method %d: %v should be %v.", method, yq, y)
+		}
+	}
+}
+
+func TestInterp1Line(t *testing.T) {
+	x := Vector{0.0, 1.0, 1.5, 3.0, 4.0, 6.0}
+	xq := Vector{0.2, 0.9, 1.2, 2.5, 3.3, 5.9}
+	line := func(v Vector) Vector {
+		return VSAdd(VSMul(v, 2.0), -1.0)
+	}
+
+	for _, method := range interpolationMethods {
+		if yq := Interp1(x, line(x), xq, method, ExtrapolationPolicyNaN); !yq.IsCloseToVector(line(xq), 0.000001) {
+			t.Errorf("Method %d: %v should be %v.", method, yq, line(xq))
+		}
+	}
+}
+
+func TestInterp1NotAKnot(t *testing.T) {
+	cubic := func(v Vector) Vector {
+		y := MakeVector(0.0, len(v))
+		for i, x := range v {
+			y[i] = x*x*x - 2.0*x*x + 0.5*x + 3.0
+		}
+		return y
+	}
+
+	x := Vector{-1.0, 0.0, 0.5, 2.0, 2.5, 4.0}
+	xq := Vector{-1.5, -0.7, 0.3, 1.1, 2.2, 3.9, 4.5}
+	if yq := Interp1(x, cubic(x), xq, InterpolationMethodSplineNotAKnot, ExtrapolationPolicyExtend); !yq.IsCloseToVector(cubic(xq), 0.000001) {
+		t.Errorf("%v should be %v.", yq, cubic(xq))
+	}
+
+	parabola := Vector{1.0, -1.0, 4.0}
+	p := Interp1(Vector{0.0, 1.0, 3.0}, parabola, Vector{2.0}, InterpolationMethodSplineNotAKnot, ExtrapolationPolicyNaN)
+	if !IsClose(p[0], 0.0, 0.000001) {
+		t.Errorf("%f should be 0.", p[0])
+	}
+}
+
+func TestInterp1SplineEnds(t *testing.T) {
+	x := Vector{0.0, 1.0, 2.5, 3.0, 4.0}
+	y := Vector{0.0, 2.0, -1.0, 1.0, 0.5}
+	delta := 0.0001
+
+	natural := Interp1(x, y, Vector{0.0, delta, 2.0 * delta}, InterpolationMethodSplineNatural, ExtrapolationPolicyNaN)
+	if d2 := (natural[2] - 2.0*natural[1] + natural[0]) / (delta * delta); !IsClose(d2, 0.0, 0.01) {
+		t.Errorf("Natural spline second derivative %f should be 0.", d2)
+	}
+
+	clamped := Interp1Clamped(x, y, Vector{0.0, delta, 4.0 - delta, 4.0}, 1.5, -2.0, ExtrapolationPolicyNaN)
+	if d1 := (clamped[1] - clamped[0]) / delta; !IsClose(d1, 1.5, 0.01) {
+		t.Errorf("Clamped spline start derivative %f should be 1.5.", d1)
+	}
+	if d1 := (clamped[3] - clamped[2]) / delta; !IsClose(d1, -2.0, 0.01) {
+		t.Errorf("Clamped spline end derivative %f should be -2.", d1)
+	}
+
+	for _, method := range []InterpolationMethod{InterpolationMethodSplineNatural, InterpolationMethodSplineNotAKnot} {
+		left := Interp1(x, y, Vector{1.0 - 2.0*delta, 1.0 - delta, 1.0}, method, ExtrapolationPolicyNaN)
+		right := Interp1(x, y, Vector{1.0, 1.0 + delta, 1.0 + 2.0*delta}, method, ExtrapolationPolicyNaN)
+
+		d2l := (left[2] - 2.0*left[1] + left[0]) / (delta * delta)
+		d2r := (right[2] - 2.0*right[1] + right[0]) / (delta * delta)
+		if !IsClose(d2l, d2r, 0.01) {
+			t.Errorf("Method %d: second derivatives %f and %f should be continuous.", method, d2l, d2r)
+		}
+	}
+}
+
+func TestInterp1Clamped(t *testing.T) {
+	x := Vector{0.0, 1.0, 2.0, 3.0}
+	xq := Vector{0.25, 1.5, 2.9}
+	if yq := Interp1Clamped(x, x, xq, 1.0, 1.0, ExtrapolationPolicyNaN); !yq.IsCloseToVector(xq, 0.000001) {
+		t.Errorf("%v should be %v.", yq, xq)
+	}
+
+	x = Vector{0.0, 2.0}
+	y := Vector{1.0, 1.0}
+	yq := Interp1Clamped(x, y, Vector{1.0}, 1.0, -1.0, ExtrapolationPolicyNaN)
+	if !IsClose(yq[0], 1.5, 0.000001) {
+		t.Errorf("%f should be 1.5.", yq[0])
+	}
+
+	if yq := Interp1Clamped(Vector{0.0}, Vector{1.0}, xq, 0.0, 0.0, ExtrapolationPolicyNaN); yq != nil {
+		t.Errorf("A single sample should return nil.")
+	}
+}
+
+func TestInterp1PCHIPMonotone(t *testing.T) {
+	x := Vector{0.0, 1.0, 2.0, 3.0, 4.0, 5.0}
+	y := Vector{0.0, 0.1, 0.2, 5.0, 5.1, 5.2}
+
+	xq := MakeVector(0.0, 101)
+	for i := range xq {
+		xq[i] = 0.05 * float64(i)
+	}
+
+	yq := Interp1(x, y, xq, InterpolationMethodPCHIP, ExtrapolationPolicyNaN)
+	for i := 1; i < len(yq); i++ {
+		if yq[i] < yq[i-1] || yq[i] > 5.2 || yq[i] < 0.0 {
+			t.Errorf("%f at %f should be monotone and within the samples.", yq[i], xq[i])
+			break
+		}
+	}
+}
+
+func TestInterp1Akima(t *testing.T) {
+	x := Vector{0.0, 1.0, 2.0, 3.0, 4.0, 5.0}
+	y := Vector{0.0, 0.0, 0.0, 1.0, 1.0, 1.0}
+
+	yq := Interp1(x, y, Vector{0.5, 1.5, 1.9, 3.1, 4.5}, InterpolationMethodAkima, ExtrapolationPolicyNaN)
+	if expected := (Vector{0.0, 0.0, 0.0, 1.0, 1.0}); !yq.IsCloseToVector(expected, 0.000001) {
+		t.Errorf("%v should be %v.", yq, expected)
+	}
+}
+
+func TestInterp1Extrapolation(t *testing.T) {
+	x := Vector{0.0, 1.0, 2.0}
+	y := Vector{1.0, 3.0, 2.0}
+	xq := Vector{-1.0, 0.5, 3.0}
+
+	nan := Interp1(x, y, xq, InterpolationMethodLinear, ExtrapolationPolicyNaN)
+	if !math.IsNaN(nan[0]) || !IsClose(nan[1], 2.0, 0.000001) || !math.IsNaN(nan[2]) {
+		t.Errorf("%v should be [NaN 2 NaN].", nan)
+	}
+
+	nearest := Interp1(x, y, xq, InterpolationMethodLinear, ExtrapolationPolicyNearest)
+	if expected := (Vector{1.0, 2.0, 2.0}); !nearest.IsCloseToVector(expected, 0.000001) {
+		t.Errorf("%v should be %v.", nearest, expected)
+	}
+
+	extend := Interp1(x, y, xq, InterpolationMethodLinear, ExtrapolationPolicyExtend)
+	if expected := (Vector{-1.0, 2.0, 1.0}); !extend.IsCloseToVector(expected, 0.000001) {
+		t.Errorf("%v should be %v.", extend, expected)
+	}
+
+	if v := Interp1(Vector{0.0, 0.0}, Vector{1.0, 2.0}, xq, InterpolationMethodLinear, ExtrapolationPolicyNaN); v != nil {
+		t.Errorf("Non-increasing samples should return nil.")
+	}
+
+	if v := Interp1(x, y, Vector{0.5}, InterpolationMethodLinear, 0); v != nil {
+		t.Errorf("An invalid extrapolation policy should return nil.")
+	}
+
+	if v := Interp1Clamped(x, y, Vector{0.5}, 0.0, 0.0, 0); v != nil {
+		t.Errorf("An invalid extrapolation policy should return nil.")
+	}
+}
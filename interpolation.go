@@ -1,35 +1,81 @@
 package gdsp
 
-// Interpolate interpolates a real-valued signal using a discrete Fourier transform.
+// Interpolate interpolates a real-valued signal using a discrete Fourier
+// transform. It is equivalent to Resample(input, len(input)*upsampleMultiple,
+// nil). The input is returned if upsampleMultiple is less than 2.
 func Interpolate(input Vector, upsampleMultiple int) Vector {
-	if upsampleMultiple < 2 || len(input)%2 != 0 {
+	if upsampleMultiple < 2 {
 		return input
 	}
 
-	fft := FFT(input.ToComplex())
-	leftHalf := fft[:len(fft)/2]
-	rightHalf := fft[len(fft)/2:]
-
-	paddedFFT := MakeVectorComplex(0.0, len(fft)*(upsampleMultiple-1))
-	paddedFFT = append(leftHalf, paddedFFT...)
-	paddedFFT = append(paddedFFT, rightHalf...)
-
-	return VSMulC(IFFT(paddedFFT), ComplexRI(upsampleMultiple)).Real()
+	return Resample(input, len(input)*upsampleMultiple, nil)
 }
 
-// InterpolateC interpolates a complex-valued signal using a discrete Fourier transform.
+// InterpolateC interpolates a complex-valued signal using a discrete Fourier
+// transform. It is equivalent to ResampleC(input, len(input)*upsampleMultiple,
+// nil). The input is returned if upsampleMultiple is less than 2.
 func InterpolateC(input VectorComplex, upsampleMultiple int) VectorComplex {
-	if upsampleMultiple < 2 || len(input)%2 != 0 {
+	if upsampleMultiple < 2 {
 		return input
 	}
 
-	fft := FFT(input)
-	leftHalf := fft[:len(fft)/2]
-	rightHalf := fft[len(fft)/2:]
+	return ResampleC(input, len(input)*upsampleMultiple, nil)
+}
+
+// Resample resamples the real-valued input vector to newLength samples using a
+// discrete Fourier transform, treating the input as one period of a periodic,
+// band-limited signal. See ResampleC.
+func Resample(input Vector, newLength int, window Vector) Vector {
+	output := ResampleC(input.ToComplex(), newLength, window)
+	if output == nil {
+		return nil
+	}
+	return output.Real()
+}
+
+// ResampleC resamples the complex-valued input vector to newLength samples using
+// a discrete Fourier transform, treating the input as one period of a periodic,
+// band-limited signal. Output sample k is the signal at time
+// k * len(input) / newLength.
+//
+// When upsampling, the spectrum is zero-padded and, for even input lengths, the
+// Nyquist bin is split equally between the positive and negative frequencies.
+// When downsampling, the spectrum is truncated and, for even output lengths, the
+// bins at the positive and negative output Nyquist frequencies are combined.
+//
+// If window is not nil, it must have the same length as the input and is
+// multiplied with the spectrum before resampling. It is ordered like the output
+// of FFT, with zero frequency first, so a symmetric window created by MakeWindow
+// should be shifted with IFFTShift. nil is returned if newLength is less than 1
+// or the window has the wrong length.
+func ResampleC(input VectorComplex, newLength int, window Vector) VectorComplex {
+	n := len(input)
+	if newLength < 1 || n == 0 || (window != nil && len(window) != n) {
+		return nil
+	}
+
+	X := FFT(input)
+	if window != nil {
+		X = VMulEC(X, window.ToComplex())
+	}
 
-	paddedFFT := MakeVectorComplex(0.0, len(fft)*(upsampleMultiple-1))
-	paddedFFT = append(leftHalf, paddedFFT...)
-	paddedFFT = append(paddedFFT, rightHalf...)
+	m := MinI(n, newLength)
+	Y := MakeVectorComplex(0.0, newLength)
+	for k := 0; k <= m/2; k++ {
+		Y[k] = X[k]
+	}
+	for k := 1; k <= (m-1)/2; k++ {
+		Y[newLength-k] = X[n-k]
+	}
+
+	if m%2 == 0 {
+		if newLength < n {
+			Y[m/2] += X[n-m/2]
+		} else if newLength > n {
+			Y[m/2] *= 0.5
+			Y[newLength-m/2] = Y[m/2]
+		}
+	}
 
-	return VSMulC(IFFT(paddedFFT), ComplexRI(upsampleMultiple))
+	return VSMulC(IFFT(Y), complex(float64(newLength)/float64(n), 0.0))
 }
@@ -2,6 +2,8 @@ package gdsp
 
 import (
 	"fmt"
+	"math"
+	"math/cmplx"
 	"testing"
 )
 
@@ -11,3 +13,74 @@ func TestInterpolation(t *testing.T) {
 
 	fmt.Printf("%v", i)
 }
+
+func TestResample(t *testing.T) {
+	signal := func(n int, length int) Vector {
+		v := MakeVector(0.0, length)
+		for k := range v {
+			tk := float64(k) * float64(n) / float64(length)
+			v[k] = math.Cos(2.0*math.Pi*2.0*tk/float64(n)) + 0.5*math.Sin(2.0*math.Pi*3.0*tk/float64(n))
+		}
+		return v
+	}
+
+	for _, lengths := range [][]int{{7, 20}, {8, 20}, {8, 21}, {9, 9}, {16, 9}, {16, 8}, {15, 7}} {
+		n, newLength := lengths[0], lengths[1]
+		y := Resample(signal(n, n), newLength, nil)
+		if expected := signal(n, newLength); !y.IsCloseToVector(expected, 0.000001) {
+			t.Errorf("%d to %d: %v should be %v.", n, newLength, y, expected)
+		}
+	}
+}
+
+func TestResampleNyquist(t *testing.T) {
+	x := Vector{1.0, -1.0, 1.0, -1.0, 1.0, -1.0}
+	y := Resample(x, 12, nil)
+
+	expected := MakeVector(0.0, 12)
+	for k := range expected {
+		expected[k] = math.Cos(math.Pi * float64(k) / 2.0)
+	}
+
+	if !y.IsCloseToVector(expected, 0.000001) {
+		t.Errorf("%v should be %v.", y, expected)
+	}
+}
+
+func TestResampleC(t *testing.T) {
+	x := MakeVectorComplex(0.0, 11)
+	for k := range x {
+		x[k] = cmplx.Exp(complex(0.0, 2.0*math.Pi*3.0*float64(k)/11.0))
+	}
+
+	y := ResampleC(x, 25, nil)
+	for k := range y {
+		expected := cmplx.Exp(complex(0.0, 2.0*math.Pi*3.0*float64(k)/25.0))
+		if !IsCloseC(y[k], expected, 0.000001) {
+			t.Errorf("%v at %d should be %v.", y[k], k, expected)
+		}
+	}
+}
+
+func TestResampleWindow(t *testing.T) {
+	x := MakeVector(0.0, 16)
+	for k := range x {
+		x[k] = 1.0 + math.Cos(2.0*math.Pi*5.0*float64(k)/16.0)
+	}
+
+	window := MakeVector(1.0, 16)
+	if y := Resample(x, 24, window); !y.IsCloseToVector(Resample(x, 24, nil), 0.000001) {
+		t.Errorf("A rectangular window should not change the output.")
+	}
+
+	for k := 4; k <= 12; k++ {
+		window[k] = 0.0
+	}
+	if y := Resample(x, 24, window); !y.IsCloseToVector(MakeVector(1.0, 24), 0.000001) {
+		t.Errorf("%v should not contain the windowed component.", y)
+	}
+
+	if y := Resample(x, 24, MakeVector(1.0, 3)); y != nil {
+		t.Errorf("A window with the wrong length should return nil.")
+	}
+}
@@ -0,0 +1,246 @@
+package gdsp
+
+import (
+	"math"
+)
+
+// LombScargle computes the Lomb-Scargle periodogram of the unevenly sampled
+// signal with sample times t and values y at the given frequencies. The
+// frequencies are in cycles per unit of t.
+//
+// The power is normalized to the range [0, 1] by the variance of y, which is the
+// fractional reduction in the sum of squares when fitting a sinusoid at each
+// frequency. The false-alarm probability of each power value is also returned;
+// see LombScargleFAP.
+//
+// At zero frequency the fitted sinusoid is a constant, which the mean removed
+// from y already accounts for, so the power is zero. The power is also zero at
+// every frequency if y is constant.
+func LombScargle(t Vector, y Vector, frequencies Vector) (Vector, Vector) {
+	if len(t) != len(y) || len(t) < 4 {
+		return nil, nil
+	}
+
+	yc := VSSub(y, Mean(y))
+	yy := VSumSq(yc)
+	power := MakeVector(0.0, len(frequencies))
+
+	for i, f := range frequencies {
+		omega := 2.0 * math.Pi * f
+
+		s2 := 0.0
+		c2 := 0.0
+		for _, ti := range t {
+			s2 += math.Sin(2.0 * omega * ti)
+			c2 += math.Cos(2.0 * omega * ti)
+		}
+		tau := 0.0
+		if omega != 0.0 {
+			tau = math.Atan2(s2, c2) / (2.0 * omega)
+		}
+
+		yc2 := 0.0
+		ys2 := 0.0
+		cc := 0.0
+		ss := 0.0
+		for j, ti := range t {
+			c := math.Cos(omega * (ti - tau))
+			s := math.Sin(omega * (ti - tau))
+			yc2 += yc[j] * c
+			ys2 += yc[j] * s
+			cc += c * c
+			ss += s * s
+		}
+
+		power[i] = lombScarglePower(yc2, ys2, cc, ss, yy)
+	}
+
+	return power, LombScargleFAP(power, t, Max(frequencies))
+}
+
+// LombScargleFast computes an approximation of the Lomb-Scargle periodogram of
+// the unevenly sampled signal with sample times t and values y at the n
+// frequencies f0 + k * df, using the method of Press and Rybicki. The
+// trigonometric sums are evaluated with an FFT after extirpolating the samples
+// on to a regular grid.
+//
+// The function returns the frequencies, the power normalized as in LombScargle
+// and the false-alarm probability of each power value.
+func LombScargleFast(t Vector, y Vector, f0 float64, df float64, n int) (Vector, Vector, Vector) {
+	if len(t) != len(y) || len(t) < 4 || n < 1 || df <= 0.0 {
+		return nil, nil, nil
+	}
+
+	w := MakeVector(1.0/float64(len(t)), len(t))
+	yc := VSSub(y, Mean(y))
+
+	sh, ch := trigSum(t, VMulE(w, yc), f0, df, n, 1.0)
+	s2, c2 := trigSum(t, w, f0, df, n, 2.0)
+	yy := VMulESum(w, VMulE(yc, yc))
+
+	frequencies := MakeVector(0.0, n)
+	power := MakeVector(0.0, n)
+	for i := 0; i < n; i++ {
+		frequencies[i] = f0 + float64(i)*df
+
+		h := math.Hypot(c2[i], s2[i])
+		c2w := 1.0
+		s2w := 0.0
+		if h > 0.0 {
+			c2w = c2[i] / h
+			s2w = s2[i] / h
+		}
+
+		cw := math.Sqrt(0.5 * (1.0 + c2w))
+		sw := math.Copysign(math.Sqrt(0.5*(1.0-c2w)), s2w)
+
+		yCos := ch[i]*cw + sh[i]*sw
+		ySin := sh[i]*cw - ch[i]*sw
+		cc := 0.5 * (1.0 + c2[i]*c2w + s2[i]*s2w)
+		ss := 0.5 * (1.0 - c2[i]*c2w - s2[i]*s2w)
+
+		power[i] = lombScarglePower(yCos, ySin, cc, ss, yy)
+	}
+
+	return frequencies, power, LombScargleFAP(power, t, Max(frequencies))
+}
+
+// lombScarglePower returns the normalized power of the sinusoid fitted with the
+// projections yc and ys of the signal on to the cosine and sine terms, whose sums
+// of squares are cc and ss, for a signal with sum of squares yy. Terms that
+// vanish at zero frequency, and signals without variance, contribute no power.
+func lombScarglePower(yc float64, ys float64, cc float64, ss float64, yy float64) float64 {
+	if yy <= 0.0 {
+		return 0.0
+	}
+
+	p := 0.0
+	if cc > 0.0 {
+		p += yc * yc / cc
+	}
+	if ss > 0.0 {
+		p += ys * ys / ss
+	}
+	return p / yy
+}
+
+// LombScargleFAP returns the false-alarm probability of each normalized
+// Lomb-Scargle power value for a signal with sample times t, searched up to the
+// maximum frequency fmax. The probability is estimated with Baluev's upper bound,
+// which accounts for the number of effectively independent frequencies.
+func LombScargleFAP(power Vector, t Vector, fmax float64) Vector {
+	n := float64(len(t))
+	nh := n - 1.0
+	nk := n - 3.0
+
+	teff := math.Sqrt(4.0 * math.Pi * lombScargleVariance(t))
+	w := fmax * teff
+
+	lgh, _ := math.Lgamma(nh / 2.0)
+	lgk, _ := math.Lgamma((nh - 1.0) / 2.0)
+	gamma := math.Sqrt(2.0/nh) * math.Exp(lgh-lgk)
+
+	fap := MakeVector(0.0, len(power))
+	for i, z := range power {
+		z = math.Min(math.Max(z, 0.0), 1.0)
+		single := math.Pow(1.0-z, 0.5*nk)
+		tau := gamma * w * math.Pow(1.0-z, 0.5*(nk-1.0)) * math.Sqrt(0.5*nh*z)
+		fap[i] = math.Min(1.0, 1.0-(1.0-single)*math.Exp(-tau))
+	}
+	return fap
+}
+
+// lombScargleVariance returns the variance of the sample times t.
+func lombScargleVariance(t Vector) float64 {
+	m := Mean(t)
+	s := 0.0
+	for _, r := range t {
+		s += (r - m) * (r - m)
+	}
+	return s / float64(len(t))
+}
+
+// trigSum computes the sums of h[j] * sin(2 pi q f t[j]) and
+// h[j] * cos(2 pi q f t[j]) for the n frequencies f = f0 + k * df, where q is the
+// frequency factor, by extirpolating h on to a regular grid and using an FFT.
+func trigSum(t Vector, h Vector, f0 float64, df float64, n int, q float64) (Vector, Vector) {
+	const oversampling = 5
+	const order = 4
+
+	df *= q
+	f0 *= q
+	t0 := Min(t)
+
+	nfft := 1
+	for nfft < n*oversampling {
+		nfft <<= 1
+	}
+
+	hc := h.ToComplex()
+	if f0 != 0.0 {
+		for j := range hc {
+			hc[j] *= complex(math.Cos(2.0*math.Pi*f0*(t[j]-t0)), math.Sin(2.0*math.Pi*f0*(t[j]-t0)))
+		}
+	}
+
+	tn := MakeVector(0.0, len(t))
+	for j := range t {
+		tn[j] = math.Mod((t[j]-t0)*float64(nfft)*df, float64(nfft))
+	}
+
+	grid := IFFT(extirpolate(tn, hc, nfft, order))
+
+	s := MakeVector(0.0, n)
+	c := MakeVector(0.0, n)
+	for k := 0; k < n; k++ {
+		g := grid[k] * complex(float64(nfft), 0.0)
+		if t0 != 0.0 {
+			f := f0 + df*float64(k)
+			g *= complex(math.Cos(2.0*math.Pi*t0*f), math.Sin(2.0*math.Pi*t0*f))
+		}
+		c[k] = real(g)
+		s[k] = imag(g)
+	}
+	return s, c
+}
+
+// extirpolate spreads the values y at the fractional positions x on to a grid of
+// length n such that Lagrange interpolation of order m on the grid recovers
+// them.
+func extirpolate(x Vector, y VectorComplex, n int, m int) VectorComplex {
+	result := MakeVectorComplex(0.0, n)
+
+	factorial := 1.0
+	for j := 2; j < m; j++ {
+		factorial *= float64(j)
+	}
+
+	for i, xi := range x {
+		if xi == math.Floor(xi) {
+			result[int(xi)%n] += y[i]
+			continue
+		}
+
+		ilo := int(xi) - m/2
+		if ilo < 0 {
+			ilo = 0
+		} else if ilo > n-m {
+			ilo = n - m
+		}
+
+		numerator := y[i]
+		for j := 0; j < m; j++ {
+			numerator *= complex(xi-float64(ilo+j), 0.0)
+		}
+
+		denominator := factorial
+		for j := 0; j < m; j++ {
+			if j > 0 {
+				denominator *= float64(j) / float64(j-m)
+			}
+			index := ilo + m - 1 - j
+			result[index] += numerator / complex(denominator*(xi-float64(index)), 0.0)
+		}
+	}
+	return result
+}
@@ -0,0 +1,74 @@
+package gdsp
+
+import (
+	"math"
+	"testing"
+)
+
+func unevenSine(n int, f float64) (Vector, Vector) {
+	t := MakeVector(0.0, n)
+	y := MakeVector(0.0, n)
+	for i := range t {
+		t[i] = float64(i) + 0.4*math.Sin(float64(i*i))
+		y[i] = math.Sin(2.0*math.Pi*f*t[i]) + 0.3*math.Cos(float64(7*i*i))
+	}
+	return t, y
+}
+
+func TestLombScargle(t *testing.T) {
+	ts, y := unevenSine(200, 0.1)
+	frequencies := MakeVector(0.0, 100)
+	for i := range frequencies {
+		frequencies[i] = 0.005 * float64(i+1)
+	}
+
+	power, fap := LombScargle(ts, y, frequencies)
+	peak := 0
+	for i := range power {
+		if power[i] > power[peak] {
+			peak = i
+		}
+	}
+
+	if !IsClose(frequencies[peak], 0.1, 0.000001) {
+		t.Errorf("Peak frequency %f should be 0.1.", frequencies[peak])
+	}
+
+	if fap[peak] > 1e-6 {
+		t.Errorf("False-alarm probability %e of the peak should be small.", fap[peak])
+	}
+
+	if fap[0] < 0.5 {
+		t.Errorf("False-alarm probability %f away from the peak should be large.", fap[0])
+	}
+}
+
+func TestLombScargleFast(t *testing.T) {
+	ts, y := unevenSine(200, 0.1)
+	frequencies, power, _ := LombScargleFast(ts, y, 0.005, 0.005, 100)
+	direct, _ := LombScargle(ts, y, frequencies)
+
+	if !power.IsCloseToVector(direct, 0.01) {
+		t.Errorf("%v should be close to %v.", power, direct)
+	}
+}
+
+func TestLombScargleDegenerate(t *testing.T) {
+	ts, y := unevenSine(200, 0.1)
+	frequencies := Vector{0.0, 0.1}
+
+	power, _ := LombScargle(ts, y, frequencies)
+	if !IsClose(power[0], 0.0, 0.000001) || power[1] < 0.5 {
+		t.Errorf("%v should be zero at zero frequency and large at 0.1.", power)
+	}
+
+	_, fast, _ := LombScargleFast(ts, y, 0.0, 0.1, 2)
+	if !fast.IsCloseToVector(power, 0.01) {
+		t.Errorf("%v should be close to %v.", fast, power)
+	}
+
+	power, _ = LombScargle(ts, MakeVector(2.0, len(ts)), frequencies)
+	if !power.IsCloseToVector(MakeVector(0.0, 2), 0.000001) {
+		t.Errorf("%v should be zero for a constant signal.", power)
+	}
+}
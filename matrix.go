@@ -1,5 +1,10 @@
 package gdsp
 
+import (
+	"math"
+	"math/cmplx"
+)
+
 // Matrix types represent an array of vectors.
 type Matrix []Vector
 
@@ -65,3 +70,633 @@ func (m MatrixComplex) FlipOrderComplex() MatrixComplex {
 	}
 	return flipped
 }
+
+// Transpose returns the transpose of the matrix.
+func (m Matrix) Transpose() Matrix {
+	return m.FlipOrder()
+}
+
+// Copy creates and returns a new matrix initialized with the elements of m.
+func (m Matrix) Copy() Matrix {
+	mc := make(Matrix, len(m))
+	for i, row := range m {
+		mc[i] = row.Copy()
+	}
+	return mc
+}
+
+// EigenSymmetric computes the eigenvalues and eigenvectors of the real symmetric
+// matrix m. The eigenvalues are returned in descending order and the rows of the
+// returned matrix are the corresponding unit-length eigenvectors.
+//
+// The matrix is reduced to tridiagonal form with Householder reflections and then
+// diagonalized with the implicit QL algorithm.
+func EigenSymmetric(m Matrix) (Vector, Matrix) {
+	n := len(m)
+	if n == 0 {
+		return nil, nil
+	}
+
+	z := m.Copy()
+	d := MakeVector(0.0, n)
+	e := MakeVector(0.0, n)
+	tred2(z, d, e)
+	tql2(z, d, e)
+
+	return sortEigen(d, z.Transpose())
+}
+
+// EigenSymmetricTridiagonal computes the eigenvalues of the real symmetric
+// tridiagonal matrix with diagonal d and subdiagonal e. The eigenvalues are
+// returned in descending order. e should have length len(d) - 1.
+func EigenSymmetricTridiagonal(d Vector, e Vector) Vector {
+	dc := d.Copy()
+	ec := MakeVector(0.0, len(d))
+	for i := 1; i < len(d) && i-1 < len(e); i++ {
+		ec[i] = e[i-1]
+	}
+
+	tql2(nil, dc, ec)
+	values, _ := sortEigen(dc, nil)
+	return values
+}
+
+// sortEigen sorts eigenvalues, and the eigenvectors in the rows of v when v is
+// non-nil, in to descending order.
+func sortEigen(d Vector, v Matrix) (Vector, Matrix) {
+	for i := 0; i < len(d)-1; i++ {
+		k := i
+		for j := i + 1; j < len(d); j++ {
+			if d[j] > d[k] {
+				k = j
+			}
+		}
+
+		if k != i {
+			d[i], d[k] = d[k], d[i]
+			if v != nil {
+				v[i], v[k] = v[k], v[i]
+			}
+		}
+	}
+	return d, v
+}
+
+// tred2 reduces the symmetric matrix z to tridiagonal form using Householder
+// reflections. On return z holds the accumulated orthogonal transformation, d the
+// diagonal and e[1:] the subdiagonal.
+func tred2(z Matrix, d Vector, e Vector) {
+	n := len(d)
+	for j := 0; j < n; j++ {
+		d[j] = z[n-1][j]
+	}
+
+	for i := n - 1; i > 0; i-- {
+		scale := 0.0
+		h := 0.0
+		for k := 0; k < i; k++ {
+			scale += math.Abs(d[k])
+		}
+
+		if scale == 0.0 {
+			e[i] = d[i-1]
+			for j := 0; j < i; j++ {
+				d[j] = z[i-1][j]
+				z[i][j] = 0.0
+				z[j][i] = 0.0
+			}
+		} else {
+			for k := 0; k < i; k++ {
+				d[k] /= scale
+				h += d[k] * d[k]
+			}
+
+			f := d[i-1]
+			g := math.Sqrt(h)
+			if f > 0 {
+				g = -g
+			}
+
+			e[i] = scale * g
+			h -= f * g
+			d[i-1] = f - g
+			for j := 0; j < i; j++ {
+				e[j] = 0.0
+			}
+
+			for j := 0; j < i; j++ {
+				f = d[j]
+				z[j][i] = f
+				g = e[j] + z[j][j]*f
+				for k := j + 1; k <= i-1; k++ {
+					g += z[k][j] * d[k]
+					e[k] += z[k][j] * f
+				}
+				e[j] = g
+			}
+
+			f = 0.0
+			for j := 0; j < i; j++ {
+				e[j] /= h
+				f += e[j] * d[j]
+			}
+
+			hh := f / (h + h)
+			for j := 0; j < i; j++ {
+				e[j] -= hh * d[j]
+			}
+
+			for j := 0; j < i; j++ {
+				f = d[j]
+				g = e[j]
+				for k := j; k <= i-1; k++ {
+					z[k][j] -= f*e[k] + g*d[k]
+				}
+				d[j] = z[i-1][j]
+				z[i][j] = 0.0
+			}
+		}
+		d[i] = h
+	}
+
+	for i := 0; i < n-1; i++ {
+		z[n-1][i] = z[i][i]
+		z[i][i] = 1.0
+		h := d[i+1]
+		if h != 0.0 {
+			for k := 0; k <= i; k++ {
+				d[k] = z[k][i+1] / h
+			}
+
+			for j := 0; j <= i; j++ {
+				g := 0.0
+				for k := 0; k <= i; k++ {
+					g += z[k][i+1] * z[k][j]
+				}
+				for k := 0; k <= i; k++ {
+					z[k][j] -= g * d[k]
+				}
+			}
+		}
+
+		for k := 0; k <= i; k++ {
+			z[k][i+1] = 0.0
+		}
+	}
+
+	for j := 0; j < n; j++ {
+		d[j] = z[n-1][j]
+		z[n-1][j] = 0.0
+	}
+	z[n-1][n-1] = 1.0
+	e[0] = 0.0
+}
+
+// tql2 diagonalizes the symmetric tridiagonal matrix with diagonal d and
+// subdiagonal e[1:] using the implicit QL algorithm. On return d holds the
+// eigenvalues. If z is non-nil the transformations are accumulated in to it so
+// that its columns hold the eigenvectors.
+func tql2(z Matrix, d Vector, e Vector) {
+	n := len(d)
+	for i := 1; i < n; i++ {
+		e[i-1] = e[i]
+	}
+	e[n-1] = 0.0
+
+	f := 0.0
+	tst1 := 0.0
+	eps := math.Pow(2.0, -52.0)
+	for l := 0; l < n; l++ {
+		tst1 = math.Max(tst1, math.Abs(d[l])+math.Abs(e[l]))
+		m := l
+		for m < n {
+			if math.Abs(e[m]) <= eps*tst1 {
+				break
+			}
+			m++
+		}
+
+		if m > l {
+			for iter := 0; iter < 64; iter++ {
+				g := d[l]
+				p := (d[l+1] - g) / (2.0 * e[l])
+				r := math.Hypot(p, 1.0)
+				if p < 0 {
+					r = -r
+				}
+
+				d[l] = e[l] / (p + r)
+				d[l+1] = e[l] * (p + r)
+				dl1 := d[l+1]
+				h := g - d[l]
+				for i := l + 2; i < n; i++ {
+					d[i] -= h
+				}
+				f += h
+
+				p = d[m]
+				c := 1.0
+				c2 := c
+				c3 := c
+				el1 := e[l+1]
+				s := 0.0
+				s2 := 0.0
+				for i := m - 1; i >= l; i-- {
+					c3 = c2
+					c2 = c
+					s2 = s
+					g = c * e[i]
+					h = c * p
+					r = math.Hypot(p, e[i])
+					e[i+1] = s * r
+					s = e[i] / r
+					c = p / r
+					p = c*d[i] - s*g
+					d[i+1] = h + s*(c*g+s*d[i])
+
+					if z != nil {
+						for k := 0; k < n; k++ {
+							h = z[k][i+1]
+							z[k][i+1] = s*z[k][i] + c*h
+							z[k][i] = c*z[k][i] - s*h
+						}
+					}
+				}
+
+				p = -s * s2 * c3 * el1 * e[l] / dl1
+				e[l] = s * p
+				d[l] = c * p
+
+				if math.Abs(e[l]) <= eps*tst1 {
+					break
+				}
+			}
+		}
+
+		d[l] += f
+		e[l] = 0.0
+	}
+}
+
+// Copy creates and returns a new matrix initialized with the elements of m.
+func (m MatrixComplex) Copy() MatrixComplex {
+	mc := make(MatrixComplex, len(m))
+	for i, row := range m {
+		mc[i] = row.Copy()
+	}
+	return mc
+}
+
+// Conj returns the conjugate matrix.
+func (m MatrixComplex) Conj() MatrixComplex {
+	mc := make(MatrixComplex, len(m))
+	for i, row := range m {
+		mc[i] = row.Conj()
+	}
+	return mc
+}
+
+// ConjTranspose returns the conjugate transpose of the matrix.
+func (m MatrixComplex) ConjTranspose() MatrixComplex {
+	return m.FlipOrderComplex().Conj()
+}
+
+// MMul performs matrix multiplication and returns the result.
+func MMul(a Matrix, b Matrix) Matrix {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+
+	m := MakeMatrix(0.0, len(a), len(b[0]))
+	for i := range a {
+		for k := range b {
+			if a[i][k] == 0.0 {
+				continue
+			}
+			for j := range b[k] {
+				m[i][j] += a[i][k] * b[k][j]
+			}
+		}
+	}
+	return m
+}
+
+// MMulC performs matrix multiplication and returns the result.
+func MMulC(a MatrixComplex, b MatrixComplex) MatrixComplex {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+
+	m := MakeMatrixComplex(0.0, len(a), len(b[0]))
+	for i := range a {
+		for k := range b {
+			if a[i][k] == 0.0 {
+				continue
+			}
+			for j := range b[k] {
+				m[i][j] += a[i][k] * b[k][j]
+			}
+		}
+	}
+	return m
+}
+
+// Solve solves the real-valued linear system a * x = b for x, where a is a
+// square matrix, using Gaussian elimination with partial pivoting. nil is
+// returned if a is singular.
+func Solve(a Matrix, b Matrix) Matrix {
+	x := SolveC(a.ToComplex(), b.ToComplex())
+	if x == nil {
+		return nil
+	}
+	return x.Real()
+}
+
+// SolveC solves the linear system a * x = b for x, where a is a square matrix,
+// using Gaussian elimination with partial pivoting. nil is returned if a is
+// singular.
+func SolveC(a MatrixComplex, b MatrixComplex) MatrixComplex {
+	n := len(a)
+	if n == 0 || len(b) != n {
+		return nil
+	}
+
+	ac := a.Copy()
+	x := b.Copy()
+	for k := 0; k < n; k++ {
+		pivot := k
+		for i := k + 1; i < n; i++ {
+			if cmplx.Abs(ac[i][k]) > cmplx.Abs(ac[pivot][k]) {
+				pivot = i
+			}
+		}
+
+		if ac[pivot][k] == 0.0 {
+			return nil
+		}
+
+		ac[k], ac[pivot] = ac[pivot], ac[k]
+		x[k], x[pivot] = x[pivot], x[k]
+
+		for i := k + 1; i < n; i++ {
+			f := ac[i][k] / ac[k][k]
+			for j := k; j < n; j++ {
+				ac[i][j] -= f * ac[k][j]
+			}
+			for j := range x[i] {
+				x[i][j] -= f * x[k][j]
+			}
+		}
+	}
+
+	for k := n - 1; k >= 0; k-- {
+		for j := range x[k] {
+			for i := k + 1; i < n; i++ {
+				x[k][j] -= ac[k][i] * x[i][j]
+			}
+			x[k][j] /= ac[k][k]
+		}
+	}
+	return x
+}
+
+// EigenHermitian computes the eigenvalues and eigenvectors of the Hermitian
+// matrix m. The eigenvalues are returned in descending order and the rows of the
+// returned matrix are the corresponding unit-length eigenvectors.
+//
+// The n by n Hermitian matrix A + iB is decomposed through the 2n by 2n real
+// symmetric matrix [A, -B; B, A], whose eigenvalues are those of m repeated
+// twice.
+func EigenHermitian(m MatrixComplex) (Vector, MatrixComplex) {
+	n := len(m)
+	if n == 0 {
+		return nil, nil
+	}
+
+	s := MakeMatrix(0.0, 2*n, 2*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			s[i][j] = real(m[i][j])
+			s[i+n][j+n] = real(m[i][j])
+			s[i][j+n] = -imag(m[i][j])
+			s[i+n][j] = imag(m[i][j])
+		}
+	}
+
+	values, vectors := EigenSymmetric(s)
+	var hValues Vector
+	var hVectors MatrixComplex
+	for i, v := range vectors {
+		z := MakeVectorComplexFromSplit(v.SubVector(0, n), v.SubVector(n, 2*n))
+		for _, u := range hVectors {
+			p := VMulESumC(u.Conj(), z)
+			z = VSubC(z, VSMulC(u, p))
+		}
+
+		norm := math.Sqrt(VESum(VSqMagC(z)))
+		if norm > 1e-6 {
+			hValues = append(hValues, values[i])
+			hVectors = append(hVectors, VSDivC(z, complex(norm, 0.0)))
+		}
+
+		if len(hVectors) == n {
+			break
+		}
+	}
+	return hValues, hVectors
+}
+
+// EigenvaluesC computes the eigenvalues of the square complex-valued matrix m.
+//
+// The matrix is reduced to upper Hessenberg form with Householder reflections and
+// the eigenvalues are found with the shifted QR algorithm.
+func EigenvaluesC(m MatrixComplex) VectorComplex {
+	n := len(m)
+	if n == 0 {
+		return nil
+	}
+
+	h := m.Copy()
+	hessenberg(h)
+
+	eps := math.Pow(2.0, -52.0)
+	values := MakeVectorComplex(0.0, n)
+	hi := n - 1
+	iter := 0
+	for hi >= 0 {
+		l := hi
+		for l > 0 {
+			if cmplx.Abs(h[l][l-1]) <= eps*(cmplx.Abs(h[l][l])+cmplx.Abs(h[l-1][l-1])) {
+				h[l][l-1] = 0.0
+				break
+			}
+			l--
+		}
+
+		if l == hi {
+			values[hi] = h[hi][hi]
+			hi--
+			iter = 0
+			continue
+		}
+
+		if iter > 100*n {
+			for i := hi; i >= 0; i-- {
+				values[i] = h[i][i]
+			}
+			break
+		}
+
+		mu := wilkinsonShift(h[hi-1][hi-1], h[hi-1][hi], h[hi][hi-1], h[hi][hi])
+		if iter%11 == 10 {
+			mu = h[hi][hi] + complex(cmplx.Abs(h[hi][hi-1]), 0.0)
+		}
+
+		qrStep(h, l, hi, mu)
+		iter++
+	}
+	return values
+}
+
+// hessenberg reduces the square matrix h to upper Hessenberg form in place.
+func hessenberg(h MatrixComplex) {
+	n := len(h)
+	for k := 0; k < n-2; k++ {
+		v := MakeVectorComplex(0.0, n-k-1)
+		norm := 0.0
+		for i := range v {
+			v[i] = h[k+1+i][k]
+			norm += real(v[i])*real(v[i]) + imag(v[i])*imag(v[i])
+		}
+		norm = math.Sqrt(norm)
+		if norm == 0.0 {
+			continue
+		}
+
+		phase := complex(1.0, 0.0)
+		if v[0] != 0.0 {
+			phase = v[0] / complex(cmplx.Abs(v[0]), 0.0)
+		}
+		v[0] += phase * complex(norm, 0.0)
+
+		vn := math.Sqrt(VESum(VSqMagC(v)))
+		v = VSDivC(v, complex(vn, 0.0))
+
+		for j := k; j < n; j++ {
+			s := complex(0.0, 0.0)
+			for i := range v {
+				s += cmplx.Conj(v[i]) * h[k+1+i][j]
+			}
+			for i := range v {
+				h[k+1+i][j] -= 2.0 * v[i] * s
+			}
+		}
+
+		for i := 0; i < n; i++ {
+			s := complex(0.0, 0.0)
+			for j := range v {
+				s += h[i][k+1+j] * v[j]
+			}
+			for j := range v {
+				h[i][k+1+j] -= 2.0 * s * cmplx.Conj(v[j])
+			}
+		}
+	}
+}
+
+// wilkinsonShift returns the eigenvalue of the 2 by 2 matrix [a, b; c, d] that
+// is closest to d.
+func wilkinsonShift(a complex128, b complex128, c complex128, d complex128) complex128 {
+	t := (a + d) / 2.0
+	disc := cmplx.Sqrt(t*t - (a*d - b*c))
+	mu1 := t + disc
+	mu2 := t - disc
+	if cmplx.Abs(mu1-d) < cmplx.Abs(mu2-d) {
+		return mu1
+	}
+	return mu2
+}
+
+// qrStep performs a single shifted QR step with shift mu on rows and columns l
+// through hi of the upper Hessenberg matrix h.
+func qrStep(h MatrixComplex, l int, hi int, mu complex128) {
+	for k := l; k <= hi; k++ {
+		h[k][k] -= mu
+	}
+
+	cs := make(VectorComplex, hi-l)
+	ss := make(VectorComplex, hi-l)
+	for k := l; k < hi; k++ {
+		a := h[k][k]
+		b := h[k+1][k]
+		r := math.Hypot(cmplx.Abs(a), cmplx.Abs(b))
+		c := complex(1.0, 0.0)
+		s := complex(0.0, 0.0)
+		if r != 0.0 {
+			c = a / complex(r, 0.0)
+			s = b / complex(r, 0.0)
+		}
+		cs[k-l] = c
+		ss[k-l] = s
+
+		for j := k; j <= hi; j++ {
+			x := h[k][j]
+			y := h[k+1][j]
+			h[k][j] = cmplx.Conj(c)*x + cmplx.Conj(s)*y
+			h[k+1][j] = -s*x + c*y
+		}
+	}
+
+	for k := l; k < hi; k++ {
+		c := cs[k-l]
+		s := ss[k-l]
+		for i := l; i <= MinI(k+2, hi); i++ {
+			x := h[i][k]
+			y := h[i][k+1]
+			h[i][k] = x*c + y*s
+			h[i][k+1] = -x*cmplx.Conj(s) + y*cmplx.Conj(c)
+		}
+	}
+
+	for k := l; k <= hi; k++ {
+		h[k][k] += mu
+	}
+}
+
+// ToComplex converts a real-valued matrix to a complex-valued matrix.
+func (m Matrix) ToComplex() MatrixComplex {
+	mc := make(MatrixComplex, len(m))
+	for i, row := range m {
+		mc[i] = row.ToComplex()
+	}
+	return mc
+}
+
+// Real returns the real components of the matrix.
+func (m MatrixComplex) Real() Matrix {
+	mr := make(Matrix, len(m))
+	for i, row := range m {
+		mr[i] = row.Real()
+	}
+	return mr
+}
+
+// Padded pads the matrix with trailing zero rows and columns so that it has the
+// given number of rows and columns.
+func (m MatrixComplex) Padded(rows int, columns int) MatrixComplex {
+	mp := MakeMatrixComplex(0.0, rows, columns)
+	for i := 0; i < len(m) && i < rows; i++ {
+		copy(mp[i], m[i])
+	}
+	return mp
+}
+
+// SubMatrix returns the submatrix with the given number of rows and columns
+// starting at the given row and column.
+func (m MatrixComplex) SubMatrix(row int, column int, rows int, columns int) MatrixComplex {
+	sm := make(MatrixComplex, rows)
+	for i := range sm {
+		sm[i] = m[row+i].SubVector(column, column+columns)
+	}
+	return sm
+}
@@ -0,0 +1,111 @@
+package gdsp
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEigenSymmetric(t *testing.T) {
+	m := Matrix{
+		Vector{4.0, 1.0, 2.0},
+		Vector{1.0, 3.0, 0.5},
+		Vector{2.0, 0.5, 5.0},
+	}
+
+	values, vectors := EigenSymmetric(m)
+	for i := 1; i < len(values); i++ {
+		if values[i] > values[i-1] {
+			t.Errorf("Eigenvalues %v are not in descending order.", values)
+		}
+	}
+
+	for i, v := range vectors {
+		for r := range m {
+			mv := VMulESum(m[r], v)
+			if math.Abs(mv-values[i]*v[r]) > 0.000001 {
+				t.Errorf("Eigenpair %d does not satisfy Av = λv.", i)
+			}
+		}
+	}
+}
+
+func TestEigenSymmetricTridiagonal(t *testing.T) {
+	values := EigenSymmetricTridiagonal(Vector{2.0, 2.0, 2.0}, Vector{-1.0, -1.0})
+	expected := Vector{2.0 + math.Sqrt2, 2.0, 2.0 - math.Sqrt2}
+
+	if !values.IsCloseToVector(expected, 0.000001) {
+		t.Errorf("%v should be %v.", values, expected)
+	}
+}
+
+func TestEigenHermitian(t *testing.T) {
+	m := MatrixComplex{
+		VectorComplex{2.0, complex(0.0, 1.0), 0.5},
+		VectorComplex{complex(0.0, -1.0), 3.0, complex(1.0, 1.0)},
+		VectorComplex{0.5, complex(1.0, -1.0), 1.0},
+	}
+
+	values, vectors := EigenHermitian(m)
+	if len(values) != 3 || len(vectors) != 3 {
+		t.Fatalf("There should be 3 eigenpairs (%d, %d).", len(values), len(vectors))
+	}
+
+	for i, v := range vectors {
+		for r := range m {
+			mv := VMulESumC(m[r], v)
+			if !IsCloseC(mv, complex(values[i], 0.0)*v[r], 0.000001) {
+				t.Errorf("Eigenpair %d does not satisfy Av = λv.", i)
+			}
+		}
+	}
+}
+
+func TestEigenvaluesC(t *testing.T) {
+	m := MatrixComplex{
+		VectorComplex{0.0, 1.0, 0.0},
+		VectorComplex{0.0, 0.0, 1.0},
+		VectorComplex{6.0, -11.0, 6.0},
+	}
+
+	values := EigenvaluesC(m)
+	for _, expected := range []complex128{1.0, 2.0, 3.0} {
+		found := false
+		for _, v := range values {
+			if IsCloseC(v, expected, 0.000001) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%v should contain %v.", values, expected)
+		}
+	}
+}
+
+func TestSolveC(t *testing.T) {
+	a := MatrixComplex{
+		VectorComplex{2.0, 1.0},
+		VectorComplex{complex(0.0, 1.0), 3.0},
+	}
+	x := MatrixComplex{VectorComplex{1.0}, VectorComplex{complex(2.0, -1.0)}}
+
+	s := SolveC(a, MMulC(a, x))
+	for i := range x {
+		if !s[i].IsCloseToVectorC(x[i], 0.000001) {
+			t.Errorf("%v should be %v.", s, x)
+		}
+	}
+}
+
+func TestSolve(t *testing.T) {
+	a := Matrix{{2.0, 1.0}, {1.0, 3.0}}
+	b := Matrix{{3.0, 1.0}, {5.0, 2.0}}
+
+	x := Solve(a, b)
+	if product := MMul(a, x); !product[0].IsCloseToVector(b[0], 0.000001) || !product[1].IsCloseToVector(b[1], 0.000001) {
+		t.Errorf("%v should be %v.", product, b)
+	}
+
+	if x := Solve(Matrix{{1.0, 2.0}, {2.0, 4.0}}, b); x != nil {
+		t.Errorf("A singular matrix should return nil.")
+	}
+}
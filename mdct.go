@@ -0,0 +1,137 @@
+package gdsp
+
+import (
+	"math"
+)
+
+// MDCT performs a modified discrete cosine transform on the real-valued input
+// vector of length 2N and returns the N coefficients
+//
+// X[k] = sum(x[n] cos(pi / N (n + 1/2 + N/2) (k + 1/2)))
+//
+// The input is folded in to N samples and transformed with a type IV DCT. N must
+// be even, otherwise nil is returned.
+func MDCT(input Vector) Vector {
+	N := len(input) / 2
+	if len(input)%2 != 0 || N%2 != 0 || N == 0 {
+		return nil
+	}
+
+	h := N / 2
+	u := MakeVector(0.0, N)
+	for n := 0; n < h; n++ {
+		u[n] = -input[N+h-1-n] - input[N+h+n]
+		u[h+n] = input[n] - input[N-1-n]
+	}
+
+	return VSMul(DCT(u, TransformTypeIV, false), 0.5)
+}
+
+// IMDCT performs an inverse modified discrete cosine transform on the N
+// real-valued coefficients of the input vector and returns the 2N samples
+//
+// y[n] = 1/N sum(X[k] cos(pi / N (n + 1/2 + N/2) (k + 1/2)))
+//
+// The output contains time-domain aliasing that is cancelled by overlapping and
+// adding the outputs of adjacent blocks. N must be even, otherwise nil is
+// returned.
+func IMDCT(input Vector) Vector {
+	N := len(input)
+	if N%2 != 0 || N == 0 {
+		return nil
+	}
+
+	h := N / 2
+	v := VSMul(DCT(input, TransformTypeIV, false), 0.5/float64(N))
+	y := MakeVector(0.0, 2*N)
+	for n := 0; n < h; n++ {
+		y[N+h-1-n] -= v[n]
+		y[N+h+n] -= v[n]
+		y[n] += v[h+n]
+		y[N-1-n] -= v[h+n]
+	}
+	return y
+}
+
+// MakeSineWindow creates and returns a sine window of the given length that
+// satisfies the Princen-Bradley condition, w[n]^2 + w[n + length/2]^2 = 1.
+func MakeSineWindow(length int) Vector {
+	w := MakeVector(0.0, length)
+	for n := range w {
+		w[n] = math.Sin(math.Pi * (float64(n) + 0.5) / float64(length))
+	}
+	return w
+}
+
+// MakeKBDWindow creates and returns a Kaiser-Bessel-derived window of the given
+// even length with shape parameter alpha. The window satisfies the
+// Princen-Bradley condition, w[n]^2 + w[n + length/2]^2 = 1. nil is returned if
+// length is not positive or not even.
+func MakeKBDWindow(length int, alpha float64) Vector {
+	if length < 1 || length%2 != 0 {
+		return nil
+	}
+
+	N := length / 2
+	kaiser := MakeKaiserWindow(N+1, math.Pi*alpha)
+	total := VESum(kaiser)
+
+	w := MakeVector(0.0, length)
+	s := 0.0
+	for n := 0; n < N; n++ {
+		s += kaiser[n]
+		w[n] = math.Sqrt(s / total)
+		w[length-1-n] = w[n]
+	}
+	return w
+}
+
+// MDCTAnalyze splits the real-valued input vector in to frames of 2N samples
+// that overlap by N samples, applies the window of length 2N and returns the N
+// MDCT coefficients of each frame.
+//
+// The input is padded with N leading zeros and enough trailing zeros that every
+// sample is covered by two frames. If the window satisfies the Princen-Bradley
+// condition, MDCTSynthesize reconstructs the input exactly.
+func MDCTAnalyze(input Vector, window Vector) Matrix {
+	N := len(window) / 2
+	if len(window)%2 != 0 || N%2 != 0 || N == 0 {
+		return nil
+	}
+
+	frames := (len(input)+N-1)/N + 1
+	padded := input.Padded(0.0, N, 0.0, (frames+1)*N-len(input)-N)
+
+	var output Matrix
+	for i := 0; i < frames; i++ {
+		output = append(output, MDCT(VMulE(window, padded.SubVector(i*N, i*N+2*N))))
+	}
+	return output
+}
+
+// MDCTSynthesize reconstructs a signal of the given length from the frames of
+// MDCT coefficients created by MDCTAnalyze using the same window. Each frame is
+// inverse transformed, windowed and overlap-added so that the time-domain
+// aliasing of adjacent frames cancels. The output of IMDCT is scaled by 2, since
+// overlap-adding the aliased halves recovers half of the input.
+func MDCTSynthesize(frames Matrix, window Vector, length int) Vector {
+	N := len(window) / 2
+	if len(window)%2 != 0 || N%2 != 0 || N == 0 {
+		return nil
+	}
+
+	w := VSMul(window, 2.0)
+	output := MakeVector(0.0, (len(frames)+1)*N)
+	for i, frame := range frames {
+		y := VMulE(w, IMDCT(frame))
+		for n := range y {
+			output[i*N+n] += y[n]
+		}
+	}
+
+	end := N + length
+	if end > len(output) {
+		end = len(output)
+	}
+	return output.SubVector(N, end)
+}
@@ -0,0 +1,73 @@
+package gdsp
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMDCT(t *testing.T) {
+	x := MakeVector(0.0, 16)
+	for i := range x {
+		x[i] = math.Sin(float64(i*i)) + 0.25*float64(i)
+	}
+
+	N := len(x) / 2
+	expected := MakeVector(0.0, N)
+	for k := range expected {
+		for n := range x {
+			expected[k] += x[n] * math.Cos(math.Pi/float64(N)*(float64(n)+0.5+float64(N)/2.0)*(float64(k)+0.5))
+		}
+	}
+
+	X := MDCT(x)
+	if !X.IsCloseToVector(expected, 0.000001) {
+		t.Errorf("%v should be %v.", X, expected)
+	}
+
+	y := IMDCT(X)
+	for n := range y {
+		e := 0.0
+		for k := range X {
+			e += X[k] * math.Cos(math.Pi/float64(N)*(float64(n)+0.5+float64(N)/2.0)*(float64(k)+0.5))
+		}
+		e /= float64(N)
+
+		if !IsClose(y[n], e, 0.000001) {
+			t.Errorf("%f at %d should be %f.", y[n], n, e)
+		}
+	}
+
+	if y := MDCT(Vector{}); y != nil {
+		t.Errorf("MDCT of an empty vector should be nil.")
+	}
+}
+
+func TestMDCTReconstruction(t *testing.T) {
+	x := MakeVector(0.0, 101)
+	for i := range x {
+		x[i] = math.Cos(0.2*float64(i)) + math.Sin(float64(i*i))
+	}
+
+	windows := []Vector{MakeSineWindow(32), MakeKBDWindow(32, 4.0)}
+	for _, w := range windows {
+		for n := 0; n < 16; n++ {
+			if !IsClose(w[n]*w[n]+w[n+16]*w[n+16], 1.0, 0.000001) {
+				t.Fatalf("Window does not satisfy the Princen-Bradley condition at %d.", n)
+			}
+		}
+
+		frames := MDCTAnalyze(x, w)
+		y := MDCTSynthesize(frames, w, len(x))
+		if !y.IsCloseToVector(x, 0.000001) {
+			t.Errorf("%v should be %v.", y, x)
+		}
+	}
+}
+
+func TestMakeKBDWindow(t *testing.T) {
+	for _, length := range []int{0, -2, 31} {
+		if w := MakeKBDWindow(length, 4.0); w != nil {
+			t.Errorf("A length of %d should return nil.", length)
+		}
+	}
+}
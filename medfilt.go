@@ -0,0 +1,298 @@
+package gdsp
+
+import (
+	"math"
+	"sort"
+)
+
+// RankFilter replaces each sample of the real-valued input vector with the
+// sample of the given rank in the window of length samples centered on it,
+// where rank 0 is the minimum and rank length - 1 is the maximum. The input is
+// extended past its ends with the given edge mode.
+//
+// The window is maintained in two heaps split at the rank, so each output sample
+// takes O(log length) time and the filter uses O(length) memory. nil is returned
+// if length is not odd, rank is out of range or mode is EdgeModeInterp.
+func RankFilter(input Vector, length int, rank int, mode EdgeMode) Vector {
+	if length < 1 || length%2 == 0 || rank < 0 || rank >= length || !isExtensionMode(mode) {
+		return nil
+	}
+
+	half := (length - 1) / 2
+	window := makeRankWindow(rank)
+	nodes := make([]*rankNode, length)
+	for k := -half; k < half; k++ {
+		nodes[k+half] = window.add(extended(input, k, mode))
+	}
+
+	output := MakeVector(0.0, len(input))
+	for i := range output {
+		nodes[(i+2*half)%length] = window.add(extended(input, i+half, mode))
+		output[i] = window.kth()
+		window.remove(nodes[i%length])
+	}
+	return output
+}
+
+// MedianFilter replaces each sample of the real-valued input vector with the
+// median of the window of length samples centered on it. The input is extended
+// past its ends with the given edge mode. See RankFilter.
+func MedianFilter(input Vector, length int, mode EdgeMode) Vector {
+	return RankFilter(input, length, (length-1)/2, mode)
+}
+
+// PercentileFilter replaces each sample of the real-valued input vector with the
+// given percentile, from 0 to 100, of the window of length samples centered on
+// it. The percentile is rounded to the nearest rank. The input is extended past
+// its ends with the given edge mode. See RankFilter.
+func PercentileFilter(input Vector, length int, percentile float64, mode EdgeMode) Vector {
+	if percentile < 0.0 || percentile > 100.0 {
+		return nil
+	}
+	return RankFilter(input, length, int(math.Round(percentile/100.0*float64(length-1))), mode)
+}
+
+// Hampel detects outliers in the real-valued input vector with a Hampel filter
+// and replaces them with the median of the window of length samples centered on
+// them. A sample is an outlier if it differs from the window's median by more
+// than threshold times the window's scaled median absolute deviation,
+// 1.4826 * median(|x - median(x)|). The input is extended past its ends with the
+// given edge mode.
+//
+// The function returns the filtered vector and whether each sample was an
+// outlier. nil is returned if length is not odd or mode is EdgeModeInterp.
+func Hampel(input Vector, length int, threshold float64, mode EdgeMode) (Vector, []bool) {
+	medians := MedianFilter(input, length, mode)
+	if medians == nil {
+		return nil, nil
+	}
+
+	half := (length - 1) / 2
+	output := input.Copy()
+	outliers := make([]bool, len(input))
+	deviations := MakeVector(0.0, length)
+	for i, m := range medians {
+		for k := range deviations {
+			deviations[k] = math.Abs(extended(input, i-half+k, mode) - m)
+		}
+		sort.Float64s(deviations)
+
+		if math.Abs(input[i]-m) > threshold*1.4826*deviations[half] {
+			output[i] = m
+			outliers[i] = true
+		}
+	}
+	return output, outliers
+}
+
+// MedianFilter2 replaces each element of the real-valued input matrix with the
+// median of the window of rows by columns elements centered on it. The input is
+// extended past its edges along each dimension with the given edge mode. nil is
+// returned if rows or columns is not odd or mode is EdgeModeInterp.
+//
+// Like RankFilter, each output element takes O(rows log(rows * columns)) time as
+// the window slides along a row.
+func MedianFilter2(input Matrix, rows int, columns int, mode EdgeMode) Matrix {
+	if rows < 1 || rows%2 == 0 || columns < 1 || columns%2 == 0 || !isExtensionMode(mode) {
+		return nil
+	}
+
+	if len(input) == 0 || len(input[0]) == 0 {
+		return MakeMatrix(0.0, len(input), 0)
+	}
+
+	element := func(i int, j int) float64 {
+		ri := edgeIndex(i, len(input), mode)
+		cj := edgeIndex(j, len(input[0]), mode)
+		if ri < 0 || cj < 0 {
+			return 0.0
+		}
+		return input[ri][cj]
+	}
+
+	hr := (rows - 1) / 2
+	hc := (columns - 1) / 2
+	window := makeRankWindow((rows*columns - 1) / 2)
+	nodes := make([][]*rankNode, columns)
+	for j := range nodes {
+		nodes[j] = make([]*rankNode, rows)
+	}
+
+	// Columns of the window are kept in nodes by their column index modulo
+	// columns.
+	addColumn := func(i int, j int) {
+		for r := range nodes[0] {
+			nodes[(j+columns)%columns][r] = window.add(element(i-hr+r, j))
+		}
+	}
+
+	removeColumn := func(j int) {
+		for _, n := range nodes[(j+columns)%columns] {
+			window.remove(n)
+		}
+	}
+
+	output := MakeMatrix(0.0, len(input), len(input[0]))
+	for i := range output {
+		for j := -hc; j < hc; j++ {
+			addColumn(i, j)
+		}
+
+		for j := range output[i] {
+			addColumn(i, j+hc)
+			output[i][j] = window.kth()
+			removeColumn(j - hc)
+		}
+
+		for j := len(output[i]) - hc; j < len(output[i])+hc; j++ {
+			removeColumn(j)
+		}
+	}
+	return output
+}
+
+// isExtensionMode returns whether mode extends a signal past its ends.
+func isExtensionMode(mode EdgeMode) bool {
+	return mode == EdgeModeMirror || mode == EdgeModeNearest || mode == EdgeModeWrap || mode == EdgeModeConstant
+}
+
+// rankWindow keeps a window of values split into two heaps at a fixed rank, so
+// that values can be added and removed and the value of that rank selected in
+// O(log w) time for a window of w values. The lower heap holds the rank + 1
+// smallest values with the largest on top, and the upper heap holds the rest
+// with the smallest on top.
+type rankWindow struct {
+	rank  int
+	lower rankHeap
+	upper rankHeap
+}
+
+// rankNode is a value in a rankWindow along with its position in one of the
+// window's heaps.
+type rankNode struct {
+	value float64
+	heap  *rankHeap
+	index int
+}
+
+// makeRankWindow creates an empty window that selects values of the given rank,
+// where rank 0 is the smallest.
+func makeRankWindow(rank int) *rankWindow {
+	return &rankWindow{
+		rank:  rank,
+		lower: rankHeap{max: true},
+		upper: rankHeap{max: false},
+	}
+}
+
+// add adds v to the window and returns its node, which is used to remove it.
+func (w *rankWindow) add(v float64) *rankNode {
+	n := &rankNode{value: v}
+	if len(w.lower.nodes) > 0 && v < w.lower.nodes[0].value {
+		w.lower.push(n)
+	} else {
+		w.upper.push(n)
+	}
+
+	w.balance()
+	return n
+}
+
+// remove removes the value of node n from the window.
+func (w *rankWindow) remove(n *rankNode) {
+	n.heap.remove(n.index)
+	w.balance()
+}
+
+// kth returns the value of the window's rank. The window must hold more values
+// than its rank.
+func (w *rankWindow) kth() float64 {
+	return w.lower.nodes[0].value
+}
+
+// balance moves values between the heaps until the lower heap holds rank + 1
+// values, or all of the values if there are fewer.
+func (w *rankWindow) balance() {
+	for len(w.lower.nodes) > w.rank+1 {
+		w.upper.push(w.lower.remove(0))
+	}
+
+	for len(w.lower.nodes) < w.rank+1 && len(w.upper.nodes) > 0 {
+		w.lower.push(w.upper.remove(0))
+	}
+}
+
+// rankHeap is a binary heap of rank nodes that keeps each node's index up to
+// date so that any node can be removed. The largest value is on top of a max
+// heap and the smallest on top of a min heap.
+type rankHeap struct {
+	nodes []*rankNode
+	max   bool
+}
+
+// push adds node n to the heap.
+func (h *rankHeap) push(n *rankNode) {
+	n.heap = h
+	n.index = len(h.nodes)
+	h.nodes = append(h.nodes, n)
+	h.up(n.index)
+}
+
+// remove removes and returns the node at index i.
+func (h *rankHeap) remove(i int) *rankNode {
+	n := h.nodes[i]
+	last := len(h.nodes) - 1
+	h.swap(i, last)
+	h.nodes = h.nodes[:last]
+
+	if i < last {
+		h.down(i)
+		h.up(i)
+	}
+	return n
+}
+
+// before returns whether the node at index i belongs above the node at index j.
+func (h *rankHeap) before(i int, j int) bool {
+	if h.max {
+		return h.nodes[i].value > h.nodes[j].value
+	}
+	return h.nodes[i].value < h.nodes[j].value
+}
+
+// swap exchanges the nodes at indices i and j.
+func (h *rankHeap) swap(i int, j int) {
+	h.nodes[i], h.nodes[j] = h.nodes[j], h.nodes[i]
+	h.nodes[i].index = i
+	h.nodes[j].index = j
+}
+
+// up moves the node at index i towards the top of the heap.
+func (h *rankHeap) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.before(i, parent) {
+			return
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the node at index i towards the bottom of the heap.
+func (h *rankHeap) down(i int) {
+	for {
+		top := i
+		for _, child := range []int{2*i + 1, 2*i + 2} {
+			if child < len(h.nodes) && h.before(child, top) {
+				top = child
+			}
+		}
+
+		if top == i {
+			return
+		}
+		h.swap(i, top)
+		i = top
+	}
+}
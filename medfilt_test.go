@@ -0,0 +1,133 @@
+package gdsp
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+var edgeModes = []EdgeMode{EdgeModeMirror, EdgeModeNearest, EdgeModeWrap, EdgeModeConstant}
+
+// naiveRank returns the sample of the given rank in each window by sorting.
+func naiveRank(x Vector, length int, rank int, mode EdgeMode) Vector {
+	half := (length - 1) / 2
+	y := MakeVector(0.0, len(x))
+	for i := range y {
+		w := MakeVector(0.0, length)
+		for k := range w {
+			w[k] = extended(x, i-half+k, mode)
+		}
+		sort.Float64s(w)
+		y[i] = w[rank]
+	}
+	return y
+}
+
+func TestRankFilter(t *testing.T) {
+	x := noise(100, 3.0)
+	x[10] = x[20]
+
+	for _, mode := range edgeModes {
+		for _, rank := range []int{0, 3, 4, 8} {
+			if y := RankFilter(x, 9, rank, mode); !y.IsCloseToVector(naiveRank(x, 9, rank, mode), 0.000001) {
+				t.Errorf("Mode %d, rank %d: output does not match sorted windows.", mode, rank)
+			}
+		}
+
+		if y := MedianFilter(x, 5, mode); !y.IsCloseToVector(naiveRank(x, 5, 2, mode), 0.000001) {
+			t.Errorf("Mode %d: median does not match sorted windows.", mode)
+		}
+
+		if y := PercentileFilter(x, 11, 90.0, mode); !y.IsCloseToVector(naiveRank(x, 11, 9, mode), 0.000001) {
+			t.Errorf("Mode %d: percentile does not match sorted windows.", mode)
+		}
+	}
+
+	// Many repeated values exercise ties between the heaps.
+	q := noise(200, 4.0)
+	for i := range q {
+		q[i] = math.Round(3.0 * q[i])
+	}
+
+	for rank := 0; rank < 7; rank++ {
+		if y := RankFilter(q, 7, rank, EdgeModeWrap); !y.IsCloseToVector(naiveRank(q, 7, rank, EdgeModeWrap), 0.000001) {
+			t.Errorf("Rank %d: output with repeated values does not match sorted windows.", rank)
+		}
+	}
+
+	if y := MedianFilter(Vector{3.0}, 3, EdgeModeMirror); !y.IsCloseToVector(Vector{3.0}, 0.000001) {
+		t.Errorf("%v should be [3].", y)
+	}
+
+	if y := RankFilter(x, 4, 1, EdgeModeMirror); y != nil {
+		t.Errorf("An even length should return nil.")
+	}
+
+	if y := RankFilter(x, 5, 1, EdgeModeInterp); y != nil {
+		t.Errorf("EdgeModeInterp should return nil.")
+	}
+}
+
+func TestHampel(t *testing.T) {
+	x := VSMul(noise(200, 5.0), 0.1)
+	spikes := []int{0, 17, 90, 91, 199}
+	for _, i := range spikes {
+		x[i] += 5.0
+	}
+
+	y, outliers := Hampel(x, 9, 3.0, EdgeModeMirror)
+	medians := MedianFilter(x, 9, EdgeModeMirror)
+
+	detected := 0
+	for i := range outliers {
+		if outliers[i] {
+			detected++
+			if !IsClose(y[i], medians[i], 0.000001) {
+				t.Errorf("Outlier at %d should be replaced with the median.", i)
+			}
+		} else if y[i] != x[i] {
+			t.Errorf("Sample at %d should not change.", i)
+		}
+	}
+
+	for _, i := range spikes {
+		if !outliers[i] {
+			t.Errorf("Spike at %d should be an outlier.", i)
+		}
+	}
+
+	if detected > len(spikes)+5 {
+		t.Errorf("%d outliers should be close to %d.", detected, len(spikes))
+	}
+}
+
+func TestMedianFilter2(t *testing.T) {
+	input := make(Matrix, 7)
+	for i := range input {
+		input[i] = noise(9, float64(i))
+	}
+
+	for _, mode := range edgeModes {
+		y := MedianFilter2(input, 3, 5, mode)
+		for i := range input {
+			for j := range input[i] {
+				var w Vector
+				for r := i - 1; r <= i+1; r++ {
+					for c := j - 2; c <= j+2; c++ {
+						ri, cj := edgeIndex(r, len(input), mode), edgeIndex(c, len(input[0]), mode)
+						if ri < 0 || cj < 0 {
+							w = append(w, 0.0)
+						} else {
+							w = append(w, input[ri][cj])
+						}
+					}
+				}
+				sort.Float64s(w)
+
+				if !IsClose(y[i][j], w[7], 0.000001) {
+					t.Errorf("Mode %d: %f at (%d, %d) should be %f.", mode, y[i][j], i, j, w[7])
+				}
+			}
+		}
+	}
+}
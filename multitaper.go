@@ -0,0 +1,255 @@
+package gdsp
+
+import (
+	"math"
+)
+
+// DPSS generates the first k discrete prolate spheroidal sequences (Slepian
+// tapers) of length n for the time-halfbandwidth product nw. The tapers are
+// returned as the rows of a matrix, normalized to unit energy, along with their
+// spectral concentration ratios.
+//
+// The tapers are the eigenvectors of a symmetric tridiagonal matrix with the k
+// largest eigenvalues. They are found by inverse iteration at the eigenvalues
+// computed by EigenSymmetricTridiagonal.
+func DPSS(n int, nw float64, k int) (Matrix, Vector) {
+	if n < 1 || k < 1 || k > n {
+		return nil, nil
+	}
+
+	w := nw / float64(n)
+	d := MakeVector(0.0, n)
+	e := MakeVector(0.0, n-1)
+	for i := 0; i < n; i++ {
+		x := (float64(n) - 1.0 - 2.0*float64(i)) / 2.0
+		d[i] = x * x * math.Cos(2.0*math.Pi*w)
+	}
+	for i := 0; i < n-1; i++ {
+		e[i] = float64(i+1) * float64(n-i-1) / 2.0
+	}
+
+	values := EigenSymmetricTridiagonal(d, e)
+	tapers := make(Matrix, k)
+	ratios := MakeVector(0.0, k)
+	for i := 0; i < k; i++ {
+		taper := tridiagonalInverseIteration(d, e, values[i])
+		tapers[i] = dpssSign(taper, i)
+		ratios[i] = dpssConcentration(tapers[i], w)
+	}
+
+	return tapers, ratios
+}
+
+// Multitaper estimates the power spectral density of x, sampled at fs, using
+// Thomson's multitaper method with k DPSS tapers for the time-halfbandwidth
+// product nw. Each tapered copy of x is transformed with an nfft point FFT.
+//
+// If adaptive is true the eigenspectra are combined using Thomson's adaptive
+// weighting, with the broadband bias of each taper estimated from the variance of
+// x about its mean. Otherwise they are weighted by their concentration ratios.
+//
+// The function returns the frequencies and the power spectral density estimate
+// at each frequency.
+func Multitaper(x Vector, fs float64, nw float64, k int, nfft int, adaptive bool, sides PSDSides) (Vector, Vector) {
+	if nfft < len(x) {
+		nfft = len(x)
+	}
+
+	tapers, ratios := DPSS(len(x), nw, k)
+	if tapers == nil {
+		return nil, nil
+	}
+
+	spectra := multitaperSpectra(x, tapers, nfft)
+	eigenspectra := make([]Vector, len(spectra))
+	for i, s := range spectra {
+		eigenspectra[i] = VSqMagC(s)
+	}
+
+	var p Vector
+	if adaptive {
+		variance := VSumSq(VSSub(x, Mean(x))) / float64(len(x))
+		p = multitaperAdaptive(eigenspectra, ratios, variance)
+	} else {
+		p = MakeVector(0.0, nfft)
+		for i, s := range eigenspectra {
+			p = VAdd(p, VSMul(s, ratios[i]))
+		}
+		p = VSDiv(p, VESum(ratios))
+	}
+
+	p = VSDiv(p, fs)
+	if sides == PSDSidesOne {
+		p = welchOneSided(p.ToComplex(), nfft).Real()
+	}
+
+	return welchFrequencies(nfft, fs, sides), p
+}
+
+// MultitaperFTest computes Thomson's harmonic F-test for line components in x,
+// sampled at fs, using k DPSS tapers for the time-halfbandwidth product nw.
+//
+// The function returns the frequencies, the F statistic at each frequency and
+// the complex amplitude of the line component at each frequency. Under the null
+// hypothesis the statistic has an F distribution with 2 and 2(k-1) degrees of
+// freedom.
+func MultitaperFTest(x Vector, fs float64, nw float64, k int, nfft int, sides PSDSides) (Vector, Vector, VectorComplex) {
+	if nfft < len(x) {
+		nfft = len(x)
+	}
+
+	tapers, _ := DPSS(len(x), nw, k)
+	if tapers == nil || k < 2 {
+		return nil, nil, nil
+	}
+
+	spectra := multitaperSpectra(x, tapers, nfft)
+	u := MakeVector(0.0, k)
+	for i, taper := range tapers {
+		u[i] = VESum(taper)
+	}
+	uu := VSumSq(u)
+
+	f := MakeVector(0.0, nfft)
+	mu := MakeVectorComplex(0.0, nfft)
+	for j := 0; j < nfft; j++ {
+		for i := range spectra {
+			mu[j] += complex(u[i], 0.0) * spectra[i][j]
+		}
+		mu[j] /= complex(uu, 0.0)
+
+		residual := 0.0
+		for i := range spectra {
+			r := spectra[i][j] - mu[j]*complex(u[i], 0.0)
+			residual += real(r)*real(r) + imag(r)*imag(r)
+		}
+
+		m := real(mu[j])*real(mu[j]) + imag(mu[j])*imag(mu[j])
+		f[j] = float64(k-1) * m * uu / residual
+	}
+
+	if sides == PSDSidesOne {
+		f = f.SubVector(0, nfft/2+1)
+		mu = mu.SubVector(0, nfft/2+1)
+	}
+
+	return welchFrequencies(nfft, fs, sides), f, mu
+}
+
+// multitaperSpectra returns the nfft point FFT of x multiplied by each taper.
+func multitaperSpectra(x Vector, tapers Matrix, nfft int) []VectorComplex {
+	spectra := make([]VectorComplex, len(tapers))
+	for i, taper := range tapers {
+		spectra[i] = FFT(VMulE(taper, x).ToComplex().PaddedTrailing(0.0, nfft-len(x)))
+	}
+	return spectra
+}
+
+// multitaperAdaptive combines eigenspectra using Thomson's adaptive weighting,
+// where variance is the variance of the signal.
+func multitaperAdaptive(eigenspectra []Vector, ratios Vector, variance float64) Vector {
+	n := len(eigenspectra[0])
+	p := MakeVector(0.0, n)
+	for j := 0; j < n; j++ {
+		s := eigenspectra[0][j]
+		if len(eigenspectra) > 1 {
+			s = (eigenspectra[0][j] + eigenspectra[1][j]) / 2.0
+		}
+
+		for iter := 0; iter < 100; iter++ {
+			num := 0.0
+			den := 0.0
+			for i, es := range eigenspectra {
+				dk := math.Sqrt(ratios[i]) * s / (ratios[i]*s + (1.0-ratios[i])*variance)
+				num += dk * dk * es[j]
+				den += dk * dk
+			}
+
+			next := num / den
+			if math.Abs(next-s) <= 1e-10*s {
+				s = next
+				break
+			}
+			s = next
+		}
+		p[j] = s
+	}
+	return p
+}
+
+// tridiagonalInverseIteration finds the unit-length eigenvector of the symmetric
+// tridiagonal matrix with diagonal d and subdiagonal e for the eigenvalue lambda.
+func tridiagonalInverseIteration(d Vector, e Vector, lambda float64) Vector {
+	n := len(d)
+	shift := lambda + 1e-10*math.Max(math.Abs(lambda), 1.0)
+
+	v := MakeVector(0.0, n)
+	for i := range v {
+		v[i] = 1.0 + float64(i)/float64(n)
+	}
+
+	sub := e.SubVector(0, n-1)
+	lower := sub.PaddedLeading(0.0, 1)
+	upper := sub.PaddedTrailing(0.0, 1)
+	diag := VSSub(d, shift)
+	for iter := 0; iter < 3; iter++ {
+		v = tridiagonalSolve(lower, diag, upper, v)
+		v = VSDiv(v, math.Sqrt(VSumSq(v)))
+	}
+	return v
+}
+
+// tridiagonalSolve solves the tridiagonal system with subdiagonal lower,
+// diagonal diag and superdiagonal upper for the right-hand side b using the
+// Thomas algorithm. lower[0] and upper[len(upper)-1] are ignored.
+func tridiagonalSolve(lower Vector, diag Vector, upper Vector, b Vector) Vector {
+	n := len(diag)
+	c := MakeVector(0.0, n)
+	x := b.Copy()
+
+	c[0] = upper[0] / diag[0]
+	x[0] /= diag[0]
+	for i := 1; i < n; i++ {
+		denominator := diag[i] - lower[i]*c[i-1]
+		c[i] = upper[i] / denominator
+		x[i] = (x[i] - lower[i]*x[i-1]) / denominator
+	}
+
+	for i := n - 2; i >= 0; i-- {
+		x[i] -= c[i] * x[i+1]
+	}
+	return x
+}
+
+// dpssSign fixes the sign of the kth taper so that symmetric tapers have a
+// positive sum and antisymmetric tapers start with a positive lobe.
+func dpssSign(taper Vector, k int) Vector {
+	if k%2 == 0 {
+		if VESum(taper) < 0.0 {
+			return VNeg(taper)
+		}
+		return taper
+	}
+
+	threshold := math.Max(1e-7, 1.0/float64(len(taper)))
+	for _, r := range taper {
+		if math.Abs(r) > threshold {
+			if r < 0.0 {
+				return VNeg(taper)
+			}
+			break
+		}
+	}
+	return taper
+}
+
+// dpssConcentration returns the fraction of the taper's energy within the
+// half-bandwidth w.
+func dpssConcentration(taper Vector, w float64) float64 {
+	r := ACorr(taper)
+	lambda := 2.0 * w * r[0]
+	for m := 1; m < len(taper); m++ {
+		lambda += 2.0 * r[m] * math.Sin(2.0*math.Pi*w*float64(m)) / (math.Pi * float64(m))
+	}
+	return lambda
+}
@@ -0,0 +1,84 @@
+package gdsp
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDPSS(t *testing.T) {
+	tapers, ratios := DPSS(64, 4.0, 7)
+	if len(tapers) != 7 || len(ratios) != 7 {
+		t.Fatalf("There should be 7 tapers (%d, %d).", len(tapers), len(ratios))
+	}
+
+	for i := range tapers {
+		for j := range tapers {
+			dot := VMulESum(tapers[i], tapers[j])
+			expected := 0.0
+			if i == j {
+				expected = 1.0
+			}
+			if math.Abs(dot-expected) > 0.000001 {
+				t.Errorf("Tapers %d and %d have inner product %f.", i, j, dot)
+			}
+		}
+	}
+
+	for i := 1; i < len(ratios); i++ {
+		if ratios[i] > ratios[i-1] || ratios[i] < 0.9 {
+			t.Errorf("Concentration ratios %v are invalid.", ratios)
+		}
+	}
+
+	if math.Abs(ratios[0]-1.0) > 0.000001 {
+		t.Errorf("%f should be close to 1.", ratios[0])
+	}
+}
+
+func TestMultitaper(t *testing.T) {
+	fs := 128.0
+	x := MakeVector(0.0, 256)
+	for i := range x {
+		x[i] = math.Sin(2.0 * math.Pi * 20.0 * float64(i) / fs)
+	}
+
+	for _, adaptive := range []bool{false, true} {
+		f, p := Multitaper(x, fs, 4.0, 7, 256, adaptive, PSDSidesOne)
+
+		peak := 0
+		for i := range p {
+			if p[i] > p[peak] {
+				peak = i
+			}
+		}
+
+		if f[peak] != 20.0 {
+			t.Errorf("Peak frequency %f should be 20.0.", f[peak])
+		}
+
+		power := VESum(p) * fs / 256.0
+		if math.Abs(power-0.5) > 0.05 {
+			t.Errorf("Total power %f should be 0.5.", power)
+		}
+	}
+}
+
+func TestMultitaperFTest(t *testing.T) {
+	fs := 128.0
+	x := MakeVector(0.0, 256)
+	for i := range x {
+		x[i] = math.Sin(2.0*math.Pi*20.0*float64(i)/fs) + 0.1*math.Sin(float64(i*i))
+	}
+
+	f, F, _ := MultitaperFTest(x, fs, 4.0, 7, 256, PSDSidesOne)
+	peak := 0
+	for i := range F {
+		if F[i] > F[peak] {
+			peak = i
+		}
+	}
+
+	if f[peak] != 20.0 {
+		t.Errorf("F-test peak %f should be 20.0.", f[peak])
+	}
+}
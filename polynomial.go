@@ -0,0 +1,87 @@
+package gdsp
+
+import (
+	"math/cmplx"
+)
+
+// Roots returns the roots of the polynomial with complex-valued coefficients c,
+// ordered from the highest power to the lowest. The roots are the eigenvalues of
+// the polynomial's companion matrix, refined with Newton's method.
+func Roots(c VectorComplex) VectorComplex {
+	start := 0
+	for start < len(c) && c[start] == 0.0 {
+		start++
+	}
+
+	stop := len(c)
+	for stop > start && c[stop-1] == 0.0 {
+		stop--
+	}
+
+	zeros := len(c) - stop
+	p := c.SubVector(start, stop)
+	n := len(p) - 1
+	if n < 1 {
+		return MakeVectorComplex(0.0, zeros)
+	}
+
+	companion := MakeMatrixComplex(0.0, n, n)
+	for j := 0; j < n; j++ {
+		companion[0][j] = -p[j+1] / p[0]
+	}
+	for i := 1; i < n; i++ {
+		companion[i][i-1] = 1.0
+	}
+
+	roots := EigenvaluesC(companion)
+	for i, z := range roots {
+		for iter := 0; iter < 3; iter++ {
+			v, dv := polyvalDerivative(p, z)
+			if dv == 0.0 {
+				break
+			}
+
+			next := z - v/dv
+			nv, _ := polyvalDerivative(p, next)
+			if cmplx.Abs(nv) >= cmplx.Abs(v) {
+				break
+			}
+			z = next
+		}
+		roots[i] = z
+	}
+
+	return append(roots, MakeVectorComplex(0.0, zeros)...)
+}
+
+// PolyvalC evaluates the polynomial with complex-valued coefficients c, ordered
+// from the highest power to the lowest, at z.
+func PolyvalC(c VectorComplex, z complex128) complex128 {
+	v, _ := polyvalDerivative(c, z)
+	return v
+}
+
+// polyvalDerivative evaluates the polynomial c and its derivative at z using
+// Horner's method.
+func polyvalDerivative(c VectorComplex, z complex128) (complex128, complex128) {
+	v := complex(0.0, 0.0)
+	dv := complex(0.0, 0.0)
+	for _, ci := range c {
+		dv = dv*z + v
+		v = v*z + ci
+	}
+	return v, dv
+}
+
+// Poly returns the coefficients of the monic polynomial whose roots are given,
+// ordered from the highest power to the lowest.
+func Poly(roots VectorComplex) VectorComplex {
+	c := MakeVectorComplex(0.0, len(roots)+1)
+	c[0] = 1.0
+	for i, r := range roots {
+		for j := i + 1; j > 0; j-- {
+			c[j] -= r * c[j-1]
+		}
+	}
+	return c
+}
@@ -0,0 +1,36 @@
+package gdsp
+
+import (
+	"math/cmplx"
+	"testing"
+)
+
+func TestRoots(t *testing.T) {
+	expected := VectorComplex{complex(0.0, 1.0), complex(0.0, -1.0), 2.0, 0.0}
+
+	// (z^2 + 1)(z - 2)z
+	roots := Roots(VectorComplex{1.0, -2.0, 1.0, -2.0, 0.0})
+	if len(roots) != len(expected) {
+		t.Fatalf("There should be %d roots (%d).", len(expected), len(roots))
+	}
+
+	for _, e := range expected {
+		found := false
+		for _, r := range roots {
+			if cmplx.Abs(r-e) < 0.000001 {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%v should contain %v.", roots, e)
+		}
+	}
+}
+
+func TestPoly(t *testing.T) {
+	c := Poly(VectorComplex{1.0, -2.0, 3.0})
+	expected := VectorComplex{1.0, -2.0, -5.0, 6.0}
+	if !c.IsCloseToVectorC(expected, 0.000001) {
+		t.Errorf("%v should be %v.", c, expected)
+	}
+}
@@ -0,0 +1,153 @@
+package gdsp
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// ExponentialModel types represent a signal as a sum of damped complex
+// exponentials,
+//
+// x[n] = sum(A[k] * exp(D[k] * n) * exp(i * (2 * pi * F[k] * n + P[k])))
+//
+// where A are the amplitudes, D the damping factors per sample, F the
+// frequencies in cycles per sample and P the phases. A real-valued damped
+// sinusoid is represented by a pair of complex exponentials with opposite
+// frequencies and phases, each with half of the sinusoid's amplitude.
+type ExponentialModel struct {
+	Amplitudes  Vector
+	Frequencies Vector
+	Damping     Vector
+	Phases      Vector
+}
+
+// Prony fits an exponential model of p complex exponentials to the real-valued
+// vector x using the least-squares Prony method. A real damped sinusoid is made
+// of two complex exponentials, so p should be twice the number of sinusoids.
+func Prony(x Vector, p int) ExponentialModel {
+	return PronyC(x.ToComplex(), p)
+}
+
+// PronyC fits an exponential model of p complex exponentials to the
+// complex-valued vector x using the least-squares Prony method.
+//
+// The linear prediction coefficients of x are found by least squares, the
+// exponentials are the roots of the prediction polynomial and the amplitudes and
+// phases are found by a least-squares fit of the exponentials to x.
+func PronyC(x VectorComplex, p int) ExponentialModel {
+	n := len(x)
+	if p < 1 || n < 2*p {
+		return ExponentialModel{}
+	}
+
+	A := MakeMatrixComplex(0.0, n-p, p)
+	b := MakeMatrixComplex(0.0, n-p, 1)
+	for i := p; i < n; i++ {
+		for k := 1; k <= p; k++ {
+			A[i-p][k-1] = x[i-k]
+		}
+		b[i-p][0] = -x[i]
+	}
+
+	Ah := A.ConjTranspose()
+	a := SolveC(MMulC(Ah, A), MMulC(Ah, b))
+	if a == nil {
+		return ExponentialModel{}
+	}
+
+	c := MakeVectorComplex(1.0, p+1)
+	for k := 1; k <= p; k++ {
+		c[k] = a[k-1][0]
+	}
+
+	return exponentialModel(x, Roots(c))
+}
+
+// MatrixPencil fits an exponential model of p complex exponentials to the
+// real-valued vector x using the matrix pencil method with pencil parameter l. A
+// real damped sinusoid is made of two complex exponentials, so p should be twice
+// the number of sinusoids.
+func MatrixPencil(x Vector, p int, l int) ExponentialModel {
+	return MatrixPencilC(x.ToComplex(), p, l)
+}
+
+// MatrixPencilC fits an exponential model of p complex exponentials to the
+// complex-valued vector x using the matrix pencil method with pencil parameter l.
+// The pencil parameter should satisfy p <= l <= len(x) - p, and values between
+// len(x) / 3 and len(x) / 2 are least sensitive to noise.
+//
+// The exponentials are the eigenvalues of the pencil formed from the dominant p
+// right singular vectors of the (len(x) - l) by (l + 1) Hankel matrix of x.
+func MatrixPencilC(x VectorComplex, p int, l int) ExponentialModel {
+	n := len(x)
+	if p < 1 || l < p || l > n-p {
+		return ExponentialModel{}
+	}
+
+	Y := MakeMatrixComplex(0.0, n-l, l+1)
+	for i := range Y {
+		for j := range Y[i] {
+			Y[i][j] = x[i+j]
+		}
+	}
+
+	_, vectors := EigenHermitian(MMulC(Y.ConjTranspose(), Y))
+	signal := MatrixComplex(vectors[:p]).Conj().FlipOrderComplex()
+	z := shiftInvariantRoots(signal)
+	if z == nil {
+		return ExponentialModel{}
+	}
+
+	return exponentialModel(x, z)
+}
+
+// Evaluate evaluates the model at the count samples starting at sample start.
+func (m ExponentialModel) Evaluate(start int, count int) VectorComplex {
+	v := MakeVectorComplex(0.0, count)
+	for k := range m.Amplitudes {
+		h := cmplx.Rect(m.Amplitudes[k], m.Phases[k])
+		s := complex(m.Damping[k], 2.0*math.Pi*m.Frequencies[k])
+		for i := range v {
+			v[i] += h * cmplx.Exp(s*complex(float64(start+i), 0.0))
+		}
+	}
+	return v
+}
+
+// exponentialModel creates an exponential model from the exponentials z by
+// fitting their amplitudes and phases to x with least squares.
+func exponentialModel(x VectorComplex, z VectorComplex) ExponentialModel {
+	V := MakeMatrixComplex(0.0, len(x), len(z))
+	b := MakeMatrixComplex(0.0, len(x), 1)
+	for i := range x {
+		for k := range z {
+			if i == 0 {
+				V[i][k] = 1.0
+			} else {
+				V[i][k] = V[i-1][k] * z[k]
+			}
+		}
+		b[i][0] = x[i]
+	}
+
+	Vh := V.ConjTranspose()
+	h := SolveC(MMulC(Vh, V), MMulC(Vh, b))
+	if h == nil {
+		return ExponentialModel{}
+	}
+
+	m := ExponentialModel{
+		Amplitudes:  MakeVector(0.0, len(z)),
+		Frequencies: MakeVector(0.0, len(z)),
+		Damping:     MakeVector(0.0, len(z)),
+		Phases:      MakeVector(0.0, len(z)),
+	}
+
+	for k := range z {
+		m.Amplitudes[k] = cmplx.Abs(h[k][0])
+		m.Phases[k] = cmplx.Phase(h[k][0])
+		m.Damping[k] = math.Log(cmplx.Abs(z[k]))
+		m.Frequencies[k] = cmplx.Phase(z[k]) / (2.0 * math.Pi)
+	}
+	return m
+}
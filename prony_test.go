@@ -0,0 +1,74 @@
+package gdsp
+
+import (
+	"math"
+	"testing"
+)
+
+func dampedSine(start int, n int) Vector {
+	x := MakeVector(0.0, n)
+	for i := range x {
+		t := float64(start + i)
+		x[i] = 2.0*math.Exp(-0.02*t)*math.Cos(2.0*math.Pi*0.05*t+0.3) + math.Exp(-0.05*t)*math.Cos(2.0*math.Pi*0.2*t)
+	}
+	return x
+}
+
+func checkExponentialModel(t *testing.T, name string, m ExponentialModel) {
+	if len(m.Frequencies) != 4 {
+		t.Fatalf("%s: there should be 4 exponentials (%d).", name, len(m.Frequencies))
+	}
+
+	for k, f := range m.Frequencies {
+		switch {
+		case IsClose(math.Abs(f), 0.05, 0.000001):
+			if !IsClose(m.Damping[k], -0.02, 0.000001) || !IsClose(m.Amplitudes[k], 1.0, 0.000001) || !IsClose(math.Abs(m.Phases[k]), 0.3, 0.000001) {
+				t.Errorf("%s: exponential %d has incorrect parameters.", name, k)
+			}
+		case IsClose(math.Abs(f), 0.2, 0.000001):
+			if !IsClose(m.Damping[k], -0.05, 0.000001) || !IsClose(m.Amplitudes[k], 0.5, 0.000001) {
+				t.Errorf("%s: exponential %d has incorrect parameters.", name, k)
+			}
+		default:
+			t.Errorf("%s: unexpected frequency %f.", name, f)
+		}
+	}
+
+	if !m.Evaluate(0, 40).Real().IsCloseToVector(dampedSine(0, 40), 0.000001) {
+		t.Errorf("%s: the model should reconstruct the signal.", name)
+	}
+}
+
+func TestProny(t *testing.T) {
+	checkExponentialModel(t, "Prony", Prony(dampedSine(0, 40), 4))
+}
+
+func TestMatrixPencil(t *testing.T) {
+	checkExponentialModel(t, "MatrixPencil", MatrixPencil(dampedSine(0, 40), 4, 15))
+}
+
+func TestExtrapolateWithModel(t *testing.T) {
+	x := dampedSine(0, 40)
+	expected := dampedSine(40, 10)
+
+	ar := ExtrapolateWithModel(x, 10, ExtrapolationModelAutoregressive, len(x)-1)
+	if !ar.IsCloseToVector(Extrapolate(x, 10), 0.000001) {
+		t.Errorf("%v should be %v.", ar, Extrapolate(x, 10))
+	}
+
+	models := []ExtrapolationModel{ExtrapolationModelProny, ExtrapolationModelMatrixPencil}
+	for _, model := range models {
+		e := ExtrapolateWithModel(x, 10, model, 4)
+		if !e.IsCloseToVector(expected, 0.0001) {
+			t.Errorf("Model %d: %v should be %v.", model, e, expected)
+		}
+	}
+
+	if e := ExtrapolateWithModel(MakeVector(0.0, 8), 4, ExtrapolationModelAutoregressive, 3); !e.IsCloseToVector(MakeVector(0.0, 4), 0.000001) {
+		t.Errorf("%v should be zero.", e)
+	}
+
+	if e := ExtrapolateWithModelC(MakeVectorComplex(0.0, 8), 4, ExtrapolationModelAutoregressive, 3); !e.IsCloseToVectorC(MakeVectorComplex(0.0, 4), 0.000001) {
+		t.Errorf("%v should be zero.", e)
+	}
+}
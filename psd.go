@@ -0,0 +1,199 @@
+package gdsp
+
+// PSDScaling values represent the scaling of a power spectral density estimate.
+type PSDScaling int
+
+// Types of power spectral density scaling.
+const (
+	// PSDScalingDensity scales the estimate to units of power per unit frequency.
+	PSDScalingDensity PSDScaling = iota + 1
+
+	// PSDScalingSpectrum scales the estimate to units of power.
+	PSDScalingSpectrum
+)
+
+// PSDSides values represent the frequency range of a power spectral density
+// estimate.
+type PSDSides int
+
+// Types of power spectral density frequency ranges.
+const (
+	// PSDSidesOne returns the non-negative frequencies of a real-valued signal
+	// with the power of the negative frequencies folded in.
+	PSDSidesOne PSDSides = iota + 1
+
+	// PSDSidesTwo returns all frequencies in FFT order.
+	PSDSidesTwo
+)
+
+// PSDAverage values represent the method used to average segment periodograms.
+type PSDAverage int
+
+// Types of periodogram averaging.
+const (
+	PSDAverageMean PSDAverage = iota + 1
+	PSDAverageMedian
+)
+
+// Welch estimates the power spectral density of x, sampled at fs, using Welch's
+// method. The signal is split in to segments of segmentLength samples that
+// overlap by overlap samples. Each segment is detrended, windowed and
+// transformed with an nfft point FFT before the periodograms are averaged.
+//
+// The function returns the frequencies and the power spectral density estimate
+// at each frequency. If overlap is not less than segmentLength, nil is returned.
+func Welch(x Vector, fs float64, windowType WindowType, segmentLength int, overlap int, nfft int, detrend DetrendType, scaling PSDScaling, sides PSDSides, average PSDAverage) (Vector, Vector) {
+	segmentLength, nfft = welchLengths(len(x), segmentLength, nfft)
+	if segmentLength < 1 || overlap < 0 || overlap >= segmentLength {
+		return nil, nil
+	}
+
+	w := MakeWindow(windowType, segmentLength)
+	if w == nil {
+		return nil, nil
+	}
+
+	spectra := welchSpectra(x.ToComplex(), w, segmentLength, overlap, nfft, detrend)
+	periodograms := make([]Vector, len(spectra))
+	for i, s := range spectra {
+		periodograms[i] = VSMul(VSqMagC(s), welchScale(w, fs, scaling))
+	}
+
+	p := welchAverage(periodograms, average)
+	if sides == PSDSidesOne {
+		p = welchOneSided(p.ToComplex(), nfft).Real()
+	}
+
+	return welchFrequencies(nfft, fs, sides), p
+}
+
+// Bartlett estimates the power spectral density of x, sampled at fs, using
+// Bartlett's method. This is equivalent to Welch's method with a rectangular
+// window and non-overlapping segments.
+func Bartlett(x Vector, fs float64, segmentLength int, nfft int, detrend DetrendType, scaling PSDScaling, sides PSDSides, average PSDAverage) (Vector, Vector) {
+	return Welch(x, fs, WindowTypeRectangular, segmentLength, 0, nfft, detrend, scaling, sides, average)
+}
+
+// welchLengths validates the segment and FFT lengths for a signal of length n.
+func welchLengths(n int, segmentLength int, nfft int) (int, int) {
+	if segmentLength > n {
+		segmentLength = n
+	}
+
+	if nfft < segmentLength {
+		nfft = segmentLength
+	}
+
+	return segmentLength, nfft
+}
+
+// welchSpectra returns the nfft point FFT of each detrended and windowed segment
+// of x.
+func welchSpectra(x VectorComplex, w Vector, segmentLength int, overlap int, nfft int, detrend DetrendType) []VectorComplex {
+	var spectra []VectorComplex
+	step := segmentLength - overlap
+	wc := w.ToComplex()
+
+	for i := 0; i+segmentLength <= len(x); i += step {
+		segment := x.SubVector(i, i+segmentLength)
+		segment = MakeVectorComplexFromSplit(Detrend(segment.Real(), detrend), Detrend(segment.Imag(), detrend))
+		segment = VMulEC(wc, segment).PaddedTrailing(0.0, nfft-segmentLength)
+		spectra = append(spectra, FFT(segment))
+	}
+
+	return spectra
+}
+
+// welchScale returns the periodogram scale factor for window w.
+func welchScale(w Vector, fs float64, scaling PSDScaling) float64 {
+	if scaling == PSDScalingSpectrum {
+		s := VESum(w)
+		return 1.0 / (s * s)
+	}
+	return 1.0 / (fs * VSumSq(w))
+}
+
+// welchAverage averages the periodograms using the given method.
+func welchAverage(periodograms []Vector, average PSDAverage) Vector {
+	if len(periodograms) == 0 {
+		return nil
+	}
+
+	p := MakeVector(0.0, len(periodograms[0]))
+	if average == PSDAverageMedian {
+		bias := medianBias(len(periodograms))
+		bin := MakeVector(0.0, len(periodograms))
+		for k := range p {
+			for i, pg := range periodograms {
+				bin[i] = pg[k]
+			}
+			p[k] = Median(bin) / bias
+		}
+		return p
+	}
+
+	for _, pg := range periodograms {
+		p = VAdd(p, pg)
+	}
+	return VSDiv(p, float64(len(periodograms)))
+}
+
+// welchAverageC averages complex-valued periodograms using the given method. The
+// median is taken over the real and imaginary parts separately.
+func welchAverageC(periodograms []VectorComplex, average PSDAverage) VectorComplex {
+	if len(periodograms) == 0 {
+		return nil
+	}
+
+	if average == PSDAverageMedian {
+		re := make([]Vector, len(periodograms))
+		im := make([]Vector, len(periodograms))
+		for i, pg := range periodograms {
+			re[i] = pg.Real()
+			im[i] = pg.Imag()
+		}
+		return MakeVectorComplexFromSplit(welchAverage(re, average), welchAverage(im, average))
+	}
+
+	p := MakeVectorComplex(0.0, len(periodograms[0]))
+	for _, pg := range periodograms {
+		p = VAddC(p, pg)
+	}
+	return VSDivC(p, ComplexRI(len(periodograms)))
+}
+
+// medianBias returns the bias of the median of n exponentially distributed
+// periodogram values relative to their mean.
+func medianBias(n int) float64 {
+	bias := 1.0
+	for i := 1; i <= (n-1)/2; i++ {
+		k := 2.0 * float64(i)
+		bias += 1.0/(k+1.0) - 1.0/k
+	}
+	return bias
+}
+
+// welchOneSided folds the negative frequencies of the two-sided estimate p in to
+// the positive frequencies.
+func welchOneSided(p VectorComplex, nfft int) VectorComplex {
+	n := nfft/2 + 1
+	op := p.SubVector(0, n)
+	last := n
+	if nfft%2 == 0 {
+		last = n - 1
+	}
+
+	for i := 1; i < last; i++ {
+		op[i] *= 2.0
+	}
+	return op
+}
+
+// welchFrequencies returns the frequencies of an nfft point estimate at sample
+// rate fs.
+func welchFrequencies(nfft int, fs float64, sides PSDSides) Vector {
+	if sides == PSDSidesOne {
+		return RFFTFreq(nfft, fs)
+	}
+	return FFTFreq(nfft, fs)
+}
@@ -0,0 +1,65 @@
+package gdsp
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBartlettParseval(t *testing.T) {
+	x := MakeVector(0.0, 64)
+	for i := range x {
+		x[i] = math.Sin(0.3*float64(i)) + 0.5*math.Cos(1.1*float64(i))
+	}
+
+	fs := 10.0
+	f, p := Bartlett(x, fs, 64, 64, DetrendTypeNone, PSDScalingDensity, PSDSidesOne, PSDAverageMean)
+	if len(f) != 33 || len(p) != 33 {
+		t.Fatalf("Estimate should have 33 frequencies (%d, %d).", len(f), len(p))
+	}
+
+	power := VESum(p) * fs / 64.0
+	if !IsClose(power, VSumSq(x)/64.0, 0.000001) {
+		t.Errorf("%f should be %f.", power, VSumSq(x)/64.0)
+	}
+}
+
+func TestWelchSine(t *testing.T) {
+	fs := 64.0
+	x := MakeVector(0.0, 1024)
+	for i := range x {
+		x[i] = 2.0 * math.Sin(2.0*math.Pi*8.0*float64(i)/fs)
+	}
+
+	averages := []PSDAverage{PSDAverageMean, PSDAverageMedian}
+	for _, average := range averages {
+		f, p := Welch(x, fs, WindowTypeHann, 128, 64, 128, DetrendTypeConstant, PSDScalingSpectrum, PSDSidesOne, average)
+
+		peak := 0
+		for i := range p {
+			if p[i] > p[peak] {
+				peak = i
+			}
+		}
+
+		if f[peak] != 8.0 {
+			t.Errorf("Peak frequency %f should be 8.0.", f[peak])
+		}
+
+		if average == PSDAverageMean && math.Abs(p[peak]-2.0) > 0.05 {
+			t.Errorf("Peak power %f should be 2.0.", p[peak])
+		}
+	}
+}
+
+func TestWelchTwoSided(t *testing.T) {
+	x := MakeVector(1.0, 32)
+	f, p := Welch(x, 1.0, WindowTypeHann, 16, 8, 16, DetrendTypeNone, PSDScalingDensity, PSDSidesTwo, PSDAverageMean)
+
+	if len(f) != 16 || len(p) != 16 {
+		t.Fatalf("Estimate should have 16 frequencies (%d, %d).", len(f), len(p))
+	}
+
+	if f[8] != -0.5 || f[15] != -1.0/16.0 {
+		t.Errorf("Frequencies %v are not in FFT order.", f)
+	}
+}
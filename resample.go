@@ -0,0 +1,84 @@
+package gdsp
+
+// UpFirDn upsamples the real-valued input vector by up, filters it with the FIR
+// filter h and downsamples it by down. The output vector has length
+// ((len(x) - 1) * up + len(h) - 1) / down + 1.
+//
+// The filter is evaluated with a polyphase implementation that only computes the
+// output samples that are kept and skips the zeros inserted by upsampling.
+func UpFirDn(h Vector, x Vector, up int, down int) Vector {
+	return UpFirDnC(h.ToComplex(), x.ToComplex(), up, down).Real()
+}
+
+// UpFirDnC upsamples the complex-valued input vector by up, filters it with the
+// FIR filter h and downsamples it by down. The output vector has length
+// ((len(x) - 1) * up + len(h) - 1) / down + 1.
+func UpFirDnC(h VectorComplex, x VectorComplex, up int, down int) VectorComplex {
+	if up < 1 || down < 1 || len(h) == 0 || len(x) == 0 {
+		return nil
+	}
+
+	y := MakeVectorComplex(0.0, ((len(x)-1)*up+len(h)-1)/down+1)
+	for m := range y {
+		n := m * down
+		for k := n % up; k < len(h) && k <= n; k += up {
+			j := (n - k) / up
+			if j < len(x) {
+				y[m] += h[k] * x[j]
+			}
+		}
+	}
+	return y
+}
+
+// ResamplePoly resamples the real-valued input vector by the rational factor
+// up / down using a polyphase FIR filter. The output vector has length
+// ceil(len(x) * up / down).
+//
+// The anti-aliasing filter is designed with the window method using a Kaiser
+// window with beta = 5, a cutoff at the lower of the two Nyquist frequencies and
+// 20 * max(up, down) + 1 coefficients. The filter delay is removed from the
+// output.
+func ResamplePoly(x Vector, up int, down int) Vector {
+	if up < 1 || down < 1 {
+		return nil
+	}
+
+	g := gcd(up, down)
+	up /= g
+	down /= g
+	if up == 1 && down == 1 {
+		return x.Copy()
+	}
+
+	maxRate := MaxI(up, down)
+	halfLength := 10 * maxRate
+	length := 2*halfLength + 1
+	h := VSMul(FIRLowpass(length, 1.0/float64(maxRate), MakeKaiserWindow(length, 5.0)), float64(up))
+	return resamplePoly(h, x, up, down)
+}
+
+// resamplePoly resamples x by up / down with the linear-phase filter h, removing
+// the filter's delay so that the output is aligned with the input.
+func resamplePoly(h Vector, x Vector, up int, down int) Vector {
+	outLength := (len(x)*up + down - 1) / down
+	halfLength := (len(h) - 1) / 2
+
+	prePad := down - halfLength%down
+	preRemove := (halfLength + prePad) / down
+	postPad := 0
+	for ((len(x)-1)*up+len(h)+prePad+postPad-1)/down+1 < outLength+preRemove {
+		postPad++
+	}
+
+	y := UpFirDn(h.Padded(0.0, prePad, 0.0, postPad), x, up, down)
+	return y.SubVector(preRemove, preRemove+outLength)
+}
+
+// gcd returns the greatest common divisor of a and b.
+func gcd(a int, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,54 @@
+package gdsp
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUpFirDn(t *testing.T) {
+	h := Vector{1.0, 0.5, -0.25, 0.125}
+	x := Vector{1.0, 2.0, -1.0, 3.0, 0.5}
+
+	for _, rates := range [][]int{{1, 1}, {3, 1}, {1, 2}, {3, 2}, {2, 3}} {
+		up, down := rates[0], rates[1]
+		u := MakeVector(0.0, (len(x)-1)*up+1)
+		for i := range x {
+			u[i*up] = x[i]
+		}
+
+		full := Conv(h, u)
+		var expected Vector
+		for i := 0; i < len(full); i += down {
+			expected = append(expected, full[i])
+		}
+
+		if y := UpFirDn(h, x, up, down); !y.IsCloseToVector(expected, 0.000001) {
+			t.Errorf("Up %d, down %d: %v should be %v.", up, down, y, expected)
+		}
+	}
+}
+
+func TestResamplePoly(t *testing.T) {
+	x := MakeVector(0.0, 300)
+	for i := range x {
+		x[i] = math.Sin(2.0 * math.Pi * float64(i) / 50.0)
+	}
+
+	for _, rates := range [][]int{{3, 2}, {2, 3}, {160, 147}} {
+		up, down := rates[0], rates[1]
+		y := ResamplePoly(x, up, down)
+
+		expectedLength := (len(x)*up + down - 1) / down
+		if len(y) != expectedLength {
+			t.Fatalf("Up %d, down %d: length %d should be %d.", up, down, len(y), expectedLength)
+		}
+
+		for i := len(y) / 4; i < 3*len(y)/4; i++ {
+			expected := math.Sin(2.0 * math.Pi * float64(i) * float64(down) / float64(up) / 50.0)
+			if !IsClose(y[i], expected, 0.001) {
+				t.Errorf("Up %d, down %d: %f at %d should be %f.", up, down, y[i], i, expected)
+				break
+			}
+		}
+	}
+}
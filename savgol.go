@@ -0,0 +1,128 @@
+package gdsp
+
+import (
+	"math"
+)
+
+// SavitzkyGolay designs a Savitzky-Golay filter that fits a polynomial of the
+// given order to each window of length samples by least squares and returns the
+// filter coefficients that evaluate the polynomial's derivative of the given
+// order at the center of the window. delta is the spacing of the samples and
+// scales the derivative.
+//
+// For odd lengths the output at sample i is
+// sum(c[k] * x[i - (length - 1)/2 + k]). For even lengths the center of the
+// window falls halfway between two samples, so the coefficients estimate the
+// signal half a sample after x[i - length/2 + 1]; use SavitzkyGolayAt to
+// evaluate the polynomial at a sample instead. nil is returned if order is not
+// less than length or derivative is greater than order.
+func SavitzkyGolay(length int, order int, derivative int, delta float64) Vector {
+	return SavitzkyGolayAt(length, order, derivative, float64(length-1)/2.0, delta)
+}
+
+// SavitzkyGolayAt designs a Savitzky-Golay filter like SavitzkyGolay, but
+// evaluates the derivative of the fitted polynomial at position in the window,
+// where 0 is the first sample and length - 1 is the last. nil is returned if
+// order is not less than length, derivative is greater than order or position is
+// outside the window.
+func SavitzkyGolayAt(length int, order int, derivative int, position float64, delta float64) Vector {
+	fit := savitzkyGolayFit(length, order, derivative, position)
+	if fit == nil {
+		return nil
+	}
+
+	return VSMul(fit[derivative], factorial(derivative)/math.Pow(delta, float64(derivative)))
+}
+
+// SavitzkyGolayFilter applies a Savitzky-Golay filter with the given window
+// length, polynomial order and derivative order to the real-valued input vector
+// with sample spacing delta. The window of output sample i starts at
+// i - (length - 1)/2, so for even lengths it extends one sample further after i
+// than before it. Outputs near the ends of the input are computed from the
+// signal extended with the given edge mode, or, with EdgeModeInterp, from the
+// polynomial fitted to the first or last length samples. nil is returned if the
+// parameters are not valid for SavitzkyGolay or if EdgeModeInterp is used with an
+// input shorter than length.
+func SavitzkyGolayFilter(input Vector, length int, order int, derivative int, delta float64, mode EdgeMode) Vector {
+	center := (length - 1) / 2
+	c := SavitzkyGolayAt(length, order, derivative, float64(center), delta)
+	if c == nil || (mode == EdgeModeInterp && len(input) < length) {
+		return nil
+	}
+
+	output := MakeVector(0.0, len(input))
+	for i := range output {
+		for k, ck := range c {
+			output[i] += ck * extended(input, i-center+k, mode)
+		}
+	}
+
+	if mode == EdgeModeInterp {
+		fit := savitzkyGolayFit(length, order, derivative, float64(center))
+		scale := 1.0 / math.Pow(delta, float64(derivative))
+		first := savitzkyGolayPolynomial(fit, input.SubVector(0, length))
+		last := savitzkyGolayPolynomial(fit, input.SubVector(len(input)-length, len(input)))
+
+		for i := 0; i < center; i++ {
+			output[i] = polynomialDerivative(first, derivative, float64(i-center)) * scale
+		}
+
+		after := length - 1 - center
+		for i := 0; i < after; i++ {
+			output[len(output)-after+i] = polynomialDerivative(last, derivative, float64(i+1)) * scale
+		}
+	}
+	return output
+}
+
+// savitzkyGolayFit returns the matrix that maps a window of length samples to the
+// coefficients, lowest power first, of the least squares polynomial of the given
+// order centered on position in the window.
+func savitzkyGolayFit(length int, order int, derivative int, position float64) Matrix {
+	if length < 1 || order < 0 || order >= length || derivative < 0 || derivative > order {
+		return nil
+	}
+
+	if position < 0.0 || position > float64(length-1) {
+		return nil
+	}
+
+	a := MakeMatrix(0.0, length, order+1)
+	for k := range a {
+		for j := range a[k] {
+			a[k][j] = math.Pow(float64(k)-position, float64(j))
+		}
+	}
+
+	at := a.Transpose()
+	return Solve(MMul(at, a), at)
+}
+
+// savitzkyGolayPolynomial returns the coefficients of the polynomial fitted to
+// the window x.
+func savitzkyGolayPolynomial(fit Matrix, x Vector) Vector {
+	p := MakeVector(0.0, len(fit))
+	for j := range p {
+		p[j] = VMulESum(fit[j], x)
+	}
+	return p
+}
+
+// polynomialDerivative evaluates the derivative of the given order of the
+// polynomial p, with coefficients ordered from the lowest power, at t.
+func polynomialDerivative(p Vector, derivative int, t float64) float64 {
+	v := 0.0
+	for j := len(p) - 1; j >= derivative; j-- {
+		v = v*t + p[j]*factorial(j)/factorial(j-derivative)
+	}
+	return v
+}
+
+// factorial returns n!.
+func factorial(n int) float64 {
+	f := 1.0
+	for i := 2; i <= n; i++ {
+		f *= float64(i)
+	}
+	return f
+}
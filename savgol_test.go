@@ -0,0 +1,109 @@
+package gdsp
+
+import (
+	"testing"
+)
+
+func TestSavitzkyGolay(t *testing.T) {
+	c := SavitzkyGolay(5, 2, 0, 1.0)
+	expected := VSDiv(Vector{-3.0, 12.0, 17.0, 12.0, -3.0}, 35.0)
+	if !c.IsCloseToVector(expected, 0.000001) {
+		t.Errorf("%v should be %v.", c, expected)
+	}
+
+	c = SavitzkyGolay(5, 2, 1, 0.5)
+	expected = VSDiv(Vector{-2.0, -1.0, 0.0, 1.0, 2.0}, 5.0)
+	if !c.IsCloseToVector(expected, 0.000001) {
+		t.Errorf("%v should be %v.", c, expected)
+	}
+
+	c = SavitzkyGolay(4, 2, 0, 1.0)
+	expected = Vector{-0.0625, 0.5625, 0.5625, -0.0625}
+	if !c.IsCloseToVector(expected, 0.000001) {
+		t.Errorf("%v should be %v.", c, expected)
+	}
+
+	if c := SavitzkyGolay(5, 2, 3, 1.0); c != nil {
+		t.Errorf("A derivative greater than the order should return nil.")
+	}
+}
+
+func TestSavitzkyGolayAt(t *testing.T) {
+	c := SavitzkyGolayAt(5, 2, 0, 2.0, 1.0)
+	expected := SavitzkyGolay(5, 2, 0, 1.0)
+	if !c.IsCloseToVector(expected, 0.000001) {
+		t.Errorf("%v should be %v.", c, expected)
+	}
+
+	// A line through the samples evaluated at the last sample.
+	c = SavitzkyGolayAt(4, 1, 0, 3.0, 1.0)
+	expected = Vector{-0.2, 0.1, 0.4, 0.7}
+	if !c.IsCloseToVector(expected, 0.000001) {
+		t.Errorf("%v should be %v.", c, expected)
+	}
+
+	if c := SavitzkyGolayAt(4, 2, 0, 3.5, 1.0); c != nil {
+		t.Errorf("A position outside the window should return nil.")
+	}
+}
+
+func TestSavitzkyGolayFilterPolynomial(t *testing.T) {
+	delta := 0.1
+	x := MakeVector(0.0, 30)
+	dx := MakeVector(0.0, 30)
+	d2x := MakeVector(0.0, 30)
+	for i := range x {
+		ti := float64(i) * delta
+		x[i] = 2.0*ti*ti*ti - ti*ti + 3.0
+		dx[i] = 6.0*ti*ti - 2.0*ti
+		d2x[i] = 12.0*ti - 2.0
+	}
+
+	if y := SavitzkyGolayFilter(x, 7, 3, 0, delta, EdgeModeInterp); !y.IsCloseToVector(x, 0.000001) {
+		t.Errorf("%v should be %v.", y, x)
+	}
+
+	if y := SavitzkyGolayFilter(x, 9, 3, 1, delta, EdgeModeInterp); !y.IsCloseToVector(dx, 0.000001) {
+		t.Errorf("%v should be %v.", y, dx)
+	}
+
+	if y := SavitzkyGolayFilter(x, 9, 4, 2, delta, EdgeModeInterp); !y.IsCloseToVector(d2x, 0.00001) {
+		t.Errorf("%v should be %v.", y, d2x)
+	}
+
+	if y := SavitzkyGolayFilter(x, 8, 3, 0, delta, EdgeModeInterp); !y.IsCloseToVector(x, 0.000001) {
+		t.Errorf("%v should be %v.", y, x)
+	}
+
+	if y := SavitzkyGolayFilter(x, 8, 3, 1, delta, EdgeModeInterp); !y.IsCloseToVector(dx, 0.000001) {
+		t.Errorf("%v should be %v.", y, dx)
+	}
+}
+
+func TestSavitzkyGolayFilterEdges(t *testing.T) {
+	x := Vector{1.0, 2.0, 4.0, 3.0, 5.0, 0.0}
+	c := SavitzkyGolay(5, 2, 0, 1.0)
+
+	extensions := map[EdgeMode][]Vector{
+		EdgeModeMirror:   {{4.0, 2.0}, {5.0, 3.0}},
+		EdgeModeNearest:  {{1.0, 1.0}, {0.0, 0.0}},
+		EdgeModeWrap:     {{5.0, 0.0}, {1.0, 2.0}},
+		EdgeModeConstant: {{0.0, 0.0}, {0.0, 0.0}},
+	}
+
+	for mode, ext := range extensions {
+		padded := append(append(ext[0].Copy(), x...), ext[1]...)
+		expected := MakeVector(0.0, len(x))
+		for i := range expected {
+			expected[i] = VMulESum(c, padded.SubVector(i, i+5))
+		}
+
+		if y := SavitzkyGolayFilter(x, 5, 2, 0, 1.0, mode); !y.IsCloseToVector(expected, 0.000001) {
+			t.Errorf("Mode %d: %v should be %v.", mode, y, expected)
+		}
+	}
+
+	if y := SavitzkyGolayFilter(x, 7, 2, 0, 1.0, EdgeModeInterp); y != nil {
+		t.Errorf("An input shorter than the window should return nil.")
+	}
+}
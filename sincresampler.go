@@ -0,0 +1,214 @@
+package gdsp
+
+import (
+	"math"
+)
+
+// ResamplerQuality values represent the length of the interpolation kernel used
+// by a Resampler. Higher qualities have less passband ripple and aliasing at the
+// cost of more computation per output sample.
+type ResamplerQuality int
+
+// Types of resampler qualities.
+const (
+	// ResamplerQualityLow uses a kernel with 8 zero crossings on each side and a
+	// Kaiser window with beta = 6.
+	ResamplerQualityLow ResamplerQuality = iota + 1
+
+	// ResamplerQualityMedium uses a kernel with 16 zero crossings on each side
+	// and a Kaiser window with beta = 8.6.
+	ResamplerQualityMedium
+
+	// ResamplerQualityHigh uses a kernel with 32 zero crossings on each side and
+	// a Kaiser window with beta = 12.
+	ResamplerQualityHigh
+)
+
+// resamplerPrecision is the number of kernel table entries per zero crossing.
+const resamplerPrecision = 512
+
+// Resampler types resample a signal by an arbitrary, possibly time-varying ratio
+// using band-limited interpolation with a Kaiser-windowed sinc kernel. Input is
+// accepted in blocks and state is kept between blocks.
+//
+// Output sample k is the input interpolated at time k / ratio, measured in input
+// samples from the first input sample, which matches the alignment of
+// Interpolate for integer ratios. Output samples are returned once all of the
+// input they depend on has been processed, so the output lags the input by the
+// kernel's half width. Call Flush after the last block to return the remaining
+// output samples.
+//
+// The kernel widens as the ratio decreases below one, so the resampler keeps
+// enough input history for the smallest ratio it is created for.
+type Resampler struct {
+	ratio     float64
+	minRatio  float64
+	halfWidth int
+	table     Vector
+
+	history VectorComplex
+	base    float64
+	count   int
+}
+
+// MakeResampler creates a resampler with the given ratio of output sample rate to
+// input sample rate and quality. minRatio is the smallest ratio that SetRatio
+// accepts. nil is returned if minRatio is not positive, the ratio is less
+// than minRatio or the quality is not valid.
+func MakeResampler(ratio float64, minRatio float64, quality ResamplerQuality) *Resampler {
+	if minRatio <= 0.0 || ratio < minRatio {
+		return nil
+	}
+
+	var halfWidth int
+	var beta float64
+	switch quality {
+	case ResamplerQualityLow:
+		halfWidth, beta = 8, 6.0
+	case ResamplerQualityMedium:
+		halfWidth, beta = 16, 8.6
+	case ResamplerQualityHigh:
+		halfWidth, beta = 32, 12.0
+	default:
+		return nil
+	}
+
+	r := &Resampler{
+		ratio:     ratio,
+		minRatio:  minRatio,
+		halfWidth: halfWidth,
+		table:     MakeVector(0.0, halfWidth*resamplerPrecision+1),
+	}
+
+	for i := range r.table {
+		t := float64(i) / float64(resamplerPrecision)
+		u := t / float64(halfWidth)
+		r.table[i] = Sinc(t) * BesselI0(beta*math.Sqrt(1.0-u*u)) / BesselI0(beta)
+	}
+
+	r.Reset()
+	return r
+}
+
+// Reset clears the resampler's input history so that a new signal can be
+// processed.
+func (r *Resampler) Reset() {
+	r.history = MakeVectorComplex(0.0, 0)
+	r.base = 0.0
+	r.count = 0
+}
+
+// Ratio returns the resampler's current ratio of output sample rate to input
+// sample rate.
+func (r *Resampler) Ratio() float64 {
+	return r.ratio
+}
+
+// SetRatio changes the resampler's ratio of output sample rate to input sample
+// rate and returns true. The new ratio applies from the next output sample. As
+// with MakeResampler, a ratio less than the minimum ratio is not valid, and
+// false is returned without changing the ratio.
+func (r *Resampler) SetRatio(ratio float64) bool {
+	if ratio < r.minRatio {
+		return false
+	}
+
+	r.base = r.time()
+	r.count = 0
+	r.ratio = ratio
+	return true
+}
+
+// Process adds the real-valued input vector to the resampler and returns the
+// output samples that can be computed from the input processed so far.
+func (r *Resampler) Process(input Vector) Vector {
+	return r.ProcessC(input.ToComplex()).Real()
+}
+
+// ProcessC adds the complex-valued input vector to the resampler and returns the
+// output samples that can be computed from the input processed so far.
+func (r *Resampler) ProcessC(input VectorComplex) VectorComplex {
+	r.history = append(r.history, input...)
+	return r.resample(math.Inf(1))
+}
+
+// Flush returns the remaining real-valued output samples, up to the time of the
+// end of the input, treating the input after its last sample as zero. The
+// resampler is reset afterwards.
+func (r *Resampler) Flush() Vector {
+	return r.FlushC().Real()
+}
+
+// FlushC returns the remaining complex-valued output samples, up to the time of
+// the end of the input, treating the input after its last sample as zero. The
+// resampler is reset afterwards.
+func (r *Resampler) FlushC() VectorComplex {
+	end := float64(len(r.history))
+	width := int(math.Ceil(float64(r.halfWidth) / math.Min(1.0, r.ratio)))
+	r.history = r.history.PaddedTrailing(0.0, width+1)
+
+	output := r.resample(end)
+	r.Reset()
+	return output
+}
+
+// resample returns the output samples at times before end whose kernels lie
+// within the history, then discards the history that is no longer needed by the
+// widest kernel, that of the minimum ratio.
+func (r *Resampler) resample(end float64) VectorComplex {
+	scale := math.Min(1.0, r.ratio)
+	width := float64(r.halfWidth) / scale
+
+	output := MakeVectorComplex(0.0, 0)
+	for t := r.time(); t < end && int(math.Floor(t+width)) < len(r.history); t = r.time() {
+		start := MaxI(int(math.Ceil(t-width)), 0)
+		stop := int(math.Floor(t + width))
+
+		y := complex(0.0, 0.0)
+		for i := start; i <= stop; i++ {
+			y += r.history[i] * complex(r.kernel(scale*(t-float64(i))), 0.0)
+		}
+
+		output = append(output, y*complex(scale, 0.0))
+		r.count++
+	}
+
+	keep := float64(r.halfWidth) / math.Min(1.0, r.minRatio)
+	if drop := MinI(int(math.Floor(r.time()-keep)), len(r.history)); drop > 0 {
+		r.history = r.history.SubVector(drop, len(r.history))
+		r.base -= float64(drop)
+	}
+	return output
+}
+
+// time returns the time of the next output sample in input samples, relative to
+// the start of the history. Times are computed from the number of output samples
+// since the ratio last changed so that rounding errors do not accumulate.
+func (r *Resampler) time() float64 {
+	return r.base + float64(r.count)/r.ratio
+}
+
+// kernel returns the windowed sinc kernel at t, linearly interpolated from the
+// kernel table.
+func (r *Resampler) kernel(t float64) float64 {
+	x := math.Abs(t) * resamplerPrecision
+	i := int(x)
+	if i >= len(r.table)-1 {
+		return 0.0
+	}
+
+	f := x - float64(i)
+	return r.table[i] + (r.table[i+1]-r.table[i])*f
+}
+
+// ResampleSinc resamples the real-valued input vector by the given ratio of
+// output sample rate to input sample rate with a Resampler of the given quality.
+// The output vector has ceil(len(input) * ratio) samples.
+func ResampleSinc(input Vector, ratio float64, quality ResamplerQuality) Vector {
+	r := MakeResampler(ratio, ratio, quality)
+	if r == nil {
+		return nil
+	}
+
+	return append(r.Process(input), r.Flush()...)
+}
@@ -0,0 +1,126 @@
+package gdsp
+
+import (
+	"math"
+	"testing"
+)
+
+func TestResampleSincInterpolate(t *testing.T) {
+	x := sine(256, 16.0)
+	expected := Interpolate(x, 2)
+	y := ResampleSinc(x, 2.0, ResamplerQualityHigh)
+
+	if len(y) != len(expected) {
+		t.Fatalf("Length %d should be %d.", len(y), len(expected))
+	}
+
+	for i := 128; i < len(y)-128; i++ {
+		if !IsClose(y[i], expected[i], 0.001) {
+			t.Errorf("%f at %d should be %f.", y[i], i, expected[i])
+			break
+		}
+	}
+}
+
+func TestResamplerRatios(t *testing.T) {
+	x := sine(1000, 50.0)
+	for _, ratio := range []float64{1.000137, 0.5, 0.9, 3.0} {
+		for _, quality := range []ResamplerQuality{ResamplerQualityLow, ResamplerQualityMedium, ResamplerQualityHigh} {
+			y := ResampleSinc(x, ratio, quality)
+			if expected := int(math.Ceil(float64(len(x)) * ratio)); len(y) != expected {
+				t.Errorf("Ratio %f: length %d should be %d.", ratio, len(y), expected)
+			}
+
+			for i := len(y) / 4; i < 3*len(y)/4; i++ {
+				expected := math.Sin(2.0 * math.Pi * float64(i) / ratio / 50.0)
+				if !IsClose(y[i], expected, 0.005) {
+					t.Errorf("Ratio %f, quality %d: %f at %d should be %f.", ratio, quality, y[i], i, expected)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestResamplerBlocks(t *testing.T) {
+	x := sine(500, 23.0)
+	whole := ResampleSinc(x, 1.37, ResamplerQualityMedium)
+
+	r := MakeResampler(1.37, 1.37, ResamplerQualityMedium)
+	var blocks Vector
+	for _, size := range []int{1, 60, 13, 200, 226} {
+		blocks = append(blocks, r.Process(x[:size])...)
+		x = x[size:]
+	}
+	blocks = append(blocks, r.Flush()...)
+
+	if !blocks.IsCloseToVector(whole, 0.000001) {
+		t.Errorf("Block output should match the output of a single block.")
+	}
+}
+
+func TestResamplerSetRatio(t *testing.T) {
+	x := sine(2000, 80.0)
+	r := MakeResampler(1.2, 0.8, ResamplerQualityMedium)
+
+	y := r.Process(x[:1000])
+	first := len(y)
+	if !r.SetRatio(0.8) {
+		t.Errorf("A ratio above the minimum ratio should be accepted.")
+	}
+	y = append(y, r.Process(x[1000:])...)
+	y = append(y, r.Flush()...)
+
+	time := 0.0
+	for i := range y {
+		if i > 100 && i < len(y)-100 {
+			expected := math.Sin(2.0 * math.Pi * time / 80.0)
+			if !IsClose(y[i], expected, 0.005) {
+				t.Errorf("%f at %d should be %f.", y[i], i, expected)
+				break
+			}
+		}
+
+		if i < first {
+			time += 1.0 / 1.2
+		} else {
+			time += 1.0 / 0.8
+		}
+	}
+
+	if r.Ratio() != 0.8 {
+		t.Errorf("Ratio %f should be 0.8.", r.Ratio())
+	}
+
+	if r.SetRatio(0.5) || r.Ratio() != 0.8 {
+		t.Errorf("A ratio below the minimum ratio should be ignored.")
+	}
+
+	if r := MakeResampler(0.5, 0.8, ResamplerQualityMedium); r != nil {
+		t.Errorf("A ratio below the minimum ratio should return nil.")
+	}
+}
+
+func TestResamplerLowerRatio(t *testing.T) {
+	x := sine(2000, 80.0)
+	r := MakeResampler(2.0, 0.25, ResamplerQualityMedium)
+
+	// The kernel is four times wider after the ratio change, so the first
+	// output samples at the new ratio depend on input processed before it.
+	y := r.Process(x[:1000])
+	first := len(y)
+	time := float64(first) / 2.0
+	if !r.SetRatio(0.25) {
+		t.Errorf("A ratio equal to the minimum ratio should be accepted.")
+	}
+	y = append(y, r.Process(x[1000:])...)
+
+	for i := first; i < len(y); i++ {
+		expected := math.Sin(2.0 * math.Pi * time / 80.0)
+		if !IsClose(y[i], expected, 0.001) {
+			t.Errorf("%f at %d should be %f.", y[i], i, expected)
+			break
+		}
+		time += 1.0 / 0.25
+	}
+}
@@ -102,15 +102,19 @@ func Mean(v Vector) float64 {
 
 // Median returns the median of the elements of v.
 func Median(v Vector) float64 {
+	if len(v) == 0 {
+		return 0.0
+	}
+
 	vc := v.Copy()
 	sort.Float64s(vc)
-	if len(v)%2 == 0 {
-		v1 := v[len(v)/2]
-		v2 := v[len(v)/2+1]
+	if len(vc)%2 == 0 {
+		v1 := vc[len(vc)/2-1]
+		v2 := vc[len(vc)/2]
 		return (v1 + v2) / 2.0
 	}
 
-	return vc[(len(vc)-1)+1]
+	return vc[len(vc)/2]
 }
 
 // StdDev returns the standard deviation of the elements of v.
@@ -135,6 +139,48 @@ func Normalize(v Vector) Vector {
 	return nv
 }
 
+// DetrendType values represent a trend to remove from a signal.
+type DetrendType int
+
+// Types of detrending.
+const (
+	DetrendTypeNone DetrendType = iota + 1
+	DetrendTypeConstant
+	DetrendTypeLinear
+)
+
+// Detrend removes the trend given by detrendType from v. A constant trend is the
+// mean of v and a linear trend is the least-squares line through v.
+func Detrend(v Vector, detrendType DetrendType) Vector {
+	switch detrendType {
+	case DetrendTypeConstant:
+		return VSSub(v, Mean(v))
+	case DetrendTypeLinear:
+		n := float64(len(v))
+		if len(v) < 2 {
+			return VSSub(v, Mean(v))
+		}
+
+		tm := (n - 1.0) / 2.0
+		vm := Mean(v)
+		num := 0.0
+		den := 0.0
+		for i, r := range v {
+			dt := float64(i) - tm
+			num += dt * (r - vm)
+			den += dt * dt
+		}
+
+		slope := num / den
+		dv := MakeVector(0.0, len(v))
+		for i, r := range v {
+			dv[i] = r - vm - slope*(float64(i)-tm)
+		}
+		return dv
+	}
+	return v.Copy()
+}
+
 // NormalizeStrict normalizes a vector using the max and min elements.
 func NormalizeStrict(v Vector) (Vector, []float64) {
 	maxValue := Max(v)
@@ -0,0 +1,22 @@
+package gdsp
+
+import "testing"
+
+func TestMedian(t *testing.T) {
+	if m := Median(MakeVectorFromArray([]float64{3.0, 1.0, 2.0})); m != 2.0 {
+		t.Errorf("%f should be 2.0.", m)
+	}
+
+	if m := Median(MakeVectorFromArray([]float64{4.0, 1.0, 3.0, 2.0})); m != 2.5 {
+		t.Errorf("%f should be 2.5.", m)
+	}
+}
+
+func TestDetrendLinear(t *testing.T) {
+	v := MakeVectorFromArray([]float64{1.0, 3.0, 5.0, 7.0, 9.0})
+	d := Detrend(v, DetrendTypeLinear)
+
+	if !d.IsCloseToVector(MakeVector(0.0, len(v)), 0.000001) {
+		t.Errorf("%v should be zero.", d)
+	}
+}
@@ -0,0 +1,209 @@
+package gdsp
+
+import (
+	"math"
+	"math/cmplx"
+	"sort"
+)
+
+// CorrelationMatrix creates the m by m Toeplitz autocorrelation matrix of the
+// real-valued vector x from its biased autocorrelation estimate.
+func CorrelationMatrix(x Vector, m int) Matrix {
+	r := VSDiv(ACorr(x), float64(len(x)))
+	R := MakeMatrix(0.0, m, m)
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			k := i - j
+			if k < 0 {
+				k = -k
+			}
+			R[i][j] = r[k]
+		}
+	}
+	return R
+}
+
+// CorrelationMatrixC creates the m by m Hermitian Toeplitz autocorrelation matrix
+// of the complex-valued vector x from its biased autocorrelation estimate. The
+// element at row i and column j estimates E[x(n+i) x*(n+j)].
+func CorrelationMatrixC(x VectorComplex, m int) MatrixComplex {
+	r := VSDivC(ACorrC(x), ComplexRI(len(x)))
+	R := MakeMatrixComplex(0.0, m, m)
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			if i >= j {
+				R[i][j] = r[i-j]
+			} else {
+				R[i][j] = cmplx.Conj(r[j-i])
+			}
+		}
+	}
+	return R
+}
+
+// MUSIC computes the MUSIC pseudospectrum of the real-valued vector x for a
+// signal of p complex exponentials using an m by m correlation matrix. A real
+// sinusoid is made of two complex exponentials, so p should be twice the number
+// of sinusoids.
+//
+// The function returns the normalized frequencies in cycles per sample, in FFT
+// order, and the pseudospectrum at each frequency.
+func MUSIC(x Vector, p int, m int, nfft int) (Vector, Vector) {
+	return MUSICC(x.ToComplex(), p, m, nfft)
+}
+
+// MUSICC computes the MUSIC pseudospectrum of the complex-valued vector x for a
+// signal of p complex exponentials using an m by m correlation matrix.
+//
+// The function returns the normalized frequencies in cycles per sample, in FFT
+// order, and the pseudospectrum at each frequency.
+func MUSICC(x VectorComplex, p int, m int, nfft int) (Vector, Vector) {
+	noise := noiseSubspace(x, p, m)
+	if noise == nil {
+		return nil, nil
+	}
+
+	if nfft < m {
+		nfft = m
+	}
+
+	d := MakeVector(0.0, nfft)
+	for _, v := range noise {
+		d = VAdd(d, VSqMagC(FFT(v.PaddedTrailing(0.0, nfft-m))))
+	}
+
+	ps := MakeVector(0.0, nfft)
+	for i := range ps {
+		ps[i] = 1.0 / d[i]
+	}
+	return welchFrequencies(nfft, 1.0, PSDSidesTwo), ps
+}
+
+// RootMUSIC estimates the normalized frequencies, in cycles per sample, of the p
+// complex exponentials in the real-valued vector x using the root-MUSIC method
+// with an m by m correlation matrix. A real sinusoid is made of two complex
+// exponentials, so p should be twice the number of sinusoids.
+func RootMUSIC(x Vector, p int, m int) Vector {
+	return RootMUSICC(x.ToComplex(), p, m)
+}
+
+// RootMUSICC estimates the normalized frequencies, in cycles per sample, of the p
+// complex exponentials in the complex-valued vector x using the root-MUSIC
+// method with an m by m correlation matrix.
+func RootMUSICC(x VectorComplex, p int, m int) Vector {
+	noise := noiseSubspace(x, p, m)
+	if noise == nil {
+		return nil
+	}
+
+	c := MakeVectorComplex(0.0, 2*m-1)
+	for _, v := range noise {
+		for i := 0; i < m; i++ {
+			for j := 0; j < m; j++ {
+				c[j-i+m-1] += v[i] * cmplx.Conj(v[j])
+			}
+		}
+	}
+
+	// c is ordered from the lowest power, z^-(m-1), to the highest, while Roots
+	// expects the highest power first.
+	var inside VectorComplex
+	for _, z := range Roots(c.Reversed()) {
+		if cmplx.Abs(z) < 1.0 {
+			inside = append(inside, z)
+		}
+	}
+
+	sort.Slice(inside, func(i, j int) bool {
+		return 1.0-cmplx.Abs(inside[i]) < 1.0-cmplx.Abs(inside[j])
+	})
+
+	if len(inside) > p {
+		inside = inside[:p]
+	}
+	return rootFrequencies(inside)
+}
+
+// ESPRIT estimates the normalized frequencies, in cycles per sample, of the p
+// complex exponentials in the real-valued vector x using the ESPRIT method with
+// an m by m correlation matrix. A real sinusoid is made of two complex
+// exponentials, so p should be twice the number of sinusoids.
+func ESPRIT(x Vector, p int, m int) Vector {
+	return ESPRITC(x.ToComplex(), p, m)
+}
+
+// ESPRITC estimates the normalized frequencies, in cycles per sample, of the p
+// complex exponentials in the complex-valued vector x using the ESPRIT method
+// with an m by m correlation matrix.
+func ESPRITC(x VectorComplex, p int, m int) Vector {
+	if p < 1 || m <= p || len(x) < m {
+		return nil
+	}
+
+	_, vectors := EigenHermitian(CorrelationMatrixC(x, m))
+	z := shiftInvariantRoots(MatrixComplex(vectors[:p]).FlipOrderComplex())
+	if z == nil {
+		return nil
+	}
+	return rootFrequencies(z)
+}
+
+// Pisarenko estimates the normalized frequencies, in cycles per sample, of the p
+// complex exponentials in the real-valued vector x using Pisarenko harmonic
+// decomposition, along with the estimated noise variance. A real sinusoid is made
+// of two complex exponentials, so p should be twice the number of sinusoids.
+func Pisarenko(x Vector, p int) (Vector, float64) {
+	return PisarenkoC(x.ToComplex(), p)
+}
+
+// PisarenkoC estimates the normalized frequencies, in cycles per sample, of the p
+// complex exponentials in the complex-valued vector x using Pisarenko harmonic
+// decomposition, along with the estimated noise variance.
+func PisarenkoC(x VectorComplex, p int) (Vector, float64) {
+	m := p + 1
+	if p < 1 || len(x) < m {
+		return nil, 0.0
+	}
+
+	values, vectors := EigenHermitian(CorrelationMatrixC(x, m))
+	v := vectors[m-1]
+	return rootFrequencies(Roots(v.Reversed().Conj())), values[m-1]
+}
+
+// noiseSubspace returns the m - p eigenvectors of the m by m correlation matrix
+// of x with the smallest eigenvalues.
+func noiseSubspace(x VectorComplex, p int, m int) MatrixComplex {
+	if p < 1 || m <= p || len(x) < m {
+		return nil
+	}
+
+	_, vectors := EigenHermitian(CorrelationMatrixC(x, m))
+	return vectors[p:]
+}
+
+// shiftInvariantRoots returns the eigenvalues of the least-squares solution to
+// S2 = S1 * phi, where S1 and S2 are the basis s with its last and first rows
+// removed. If the columns of s span vectors of the form [1, z, z^2, ...], the
+// eigenvalues are the values of z.
+func shiftInvariantRoots(s MatrixComplex) VectorComplex {
+	s1 := s[:len(s)-1]
+	s2 := s[1:]
+
+	s1h := s1.ConjTranspose()
+	phi := SolveC(MMulC(s1h, s1), MMulC(s1h, s2))
+	if phi == nil {
+		return nil
+	}
+	return EigenvaluesC(phi)
+}
+
+// rootFrequencies returns the normalized frequencies, in cycles per sample, of
+// the angles of the roots z in ascending order.
+func rootFrequencies(z VectorComplex) Vector {
+	f := MakeVector(0.0, len(z))
+	for i, r := range z {
+		f[i] = cmplx.Phase(r) / (2.0 * math.Pi)
+	}
+	sort.Float64s(f)
+	return f
+}
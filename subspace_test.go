@@ -0,0 +1,87 @@
+package gdsp
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func twoTones() Vector {
+	x := MakeVector(0.0, 128)
+	e := noise(len(x), 4.0)
+	for i := range x {
+		n := float64(i)
+		x[i] = math.Cos(2.0*math.Pi*0.1*n) + math.Cos(2.0*math.Pi*0.13*n+1.0) + 0.01*e[i]
+	}
+	return x
+}
+
+func TestSubspaceEstimators(t *testing.T) {
+	x := twoTones()
+	expected := Vector{-0.13, -0.1, 0.1, 0.13}
+
+	checkFrequencies(t, "RootMUSIC", RootMUSIC(x, 4, 12), expected, 0.005)
+	checkFrequencies(t, "ESPRIT", ESPRIT(x, 4, 12), expected, 0.005)
+}
+
+func TestPisarenko(t *testing.T) {
+	x := MakeVector(0.0, 512)
+	e := noise(len(x), 5.0)
+	for i := range x {
+		x[i] = math.Sin(2.0*math.Pi*0.2*float64(i)) + 0.1*e[i]
+	}
+
+	f, variance := Pisarenko(x, 2)
+	checkFrequencies(t, "Pisarenko", f, Vector{-0.2, 0.2}, 0.005)
+
+	if variance <= 0.0 || variance > 0.01 {
+		t.Errorf("Noise variance %f should be close to %f.", variance, 0.01*VSumSq(e)/float64(len(e)))
+	}
+}
+
+func TestMUSIC(t *testing.T) {
+	x := twoTones()
+	f, ps := MUSIC(x, 4, 12, 512)
+
+	peaks := Vector{}
+	for i := 1; i < len(ps)/2; i++ {
+		if ps[i] > ps[i-1] && ps[i] > ps[i+1] && ps[i] > 100.0*Min(ps) {
+			peaks = append(peaks, f[i])
+		}
+	}
+
+	checkFrequencies(t, "MUSIC", peaks, Vector{0.1, 0.13}, 0.005)
+}
+
+func TestESPRITC(t *testing.T) {
+	x := MakeVectorComplex(0.0, 64)
+	for i := range x {
+		n := float64(i)
+		x[i] = complex(math.Cos(0.5*n), math.Sin(0.5*n)) + 0.5*complex(math.Cos(-1.2*n), math.Sin(-1.2*n))
+	}
+
+	expected := Vector{-1.2 / (2.0 * math.Pi), 0.5 / (2.0 * math.Pi)}
+	checkFrequencies(t, "ESPRITC", ESPRITC(x, 2, 8), expected, 0.001)
+}
+
+func TestSubspaceEstimatorsC(t *testing.T) {
+	x := MakeVectorComplex(0.0, 256)
+	e := noise(len(x), 6.0)
+	for i := range x {
+		x[i] = cmplx.Exp(complex(0.0, 2.0*math.Pi*0.1*float64(i))) + complex(0.01*e[i], 0.0)
+	}
+
+	checkFrequencies(t, "RootMUSICC", RootMUSICC(x, 1, 8), Vector{0.1}, 0.001)
+
+	f, _ := PisarenkoC(x, 1)
+	checkFrequencies(t, "PisarenkoC", f, Vector{0.1}, 0.001)
+
+	freqs, ps := MUSICC(x, 1, 8, 1000)
+	peak := 0
+	for i := range ps {
+		if ps[i] > ps[peak] {
+			peak = i
+		}
+	}
+	checkFrequencies(t, "MUSICC", Vector{freqs[peak]}, Vector{0.1}, 0.002)
+}
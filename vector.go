@@ -293,6 +293,15 @@ func VMagC(v VectorComplex) Vector {
 	return vc
 }
 
+// VSqMagC sets each element of the vector to its squared magnitude.
+func VSqMagC(v VectorComplex) Vector {
+	vc := MakeVector(0.0, len(v))
+	for i := 0; i < len(vc); i++ {
+		vc[i] = real(v[i])*real(v[i]) + imag(v[i])*imag(v[i])
+	}
+	return vc
+}
+
 // VESum adds together the elements of v and returns the result.
 func VESum(v Vector) float64 {
 	s := 0.0
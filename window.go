@@ -13,6 +13,7 @@ const (
 	WindowTypeHann WindowType = iota + 1
 	WindowTypeHamming
 	WindowTypeNuttal
+	WindowTypeRectangular
 )
 
 // Window applies a window function given by windowType to the input signal.
@@ -24,6 +25,8 @@ func Window(windowType WindowType, input VectorComplex) VectorComplex {
 		return Hamming(input)
 	case WindowTypeNuttal:
 		return Nuttal(input)
+	case WindowTypeRectangular:
+		return Rectangular(input)
 	}
 	return nil
 }
@@ -32,15 +35,78 @@ func Window(windowType WindowType, input VectorComplex) VectorComplex {
 func InverseWindow(windowType WindowType, input VectorComplex) VectorComplex {
 	switch windowType {
 	case WindowTypeHann:
-		return Hann(input)
+		return InverseHann(input)
 	case WindowTypeHamming:
-		return Hamming(input)
+		return InverseHamming(input)
 	case WindowTypeNuttal:
-		return Nuttal(input)
+		return InverseNuttal(input)
+	case WindowTypeRectangular:
+		return InverseRectangular(input)
 	}
 	return nil
 }
 
+// InverseWindowMethod values represent a method of regularizing an inverse window.
+type InverseWindowMethod int
+
+// Inverse window regularization methods.
+const (
+	// InverseWindowMethodFloor divides by the larger of the window value and
+	// epsilon.
+	InverseWindowMethodFloor InverseWindowMethod = iota + 1
+
+	// InverseWindowMethodTikhonov multiplies by w / (w^2 + epsilon).
+	InverseWindowMethodTikhonov
+)
+
+// MakeWindow creates and returns the coefficients of the window given by
+// windowType with the given length.
+func MakeWindow(windowType WindowType, length int) Vector {
+	w := Window(windowType, MakeVectorComplex(1.0, length))
+	if w == nil {
+		return nil
+	}
+	return w.Real()
+}
+
+// InverseWindowRegularized applies a numerically safe inverse of the window
+// function given by windowType to the input signal. Samples where the window is
+// close to zero are regularized using method and epsilon rather than divided by
+// zero.
+//
+// The returned mask is true for each sample whose window value was large enough
+// that the regularized inverse is accurate. For the floor method, the masked
+// samples are those with |w| >= epsilon, whose inverse is exact. For the
+// Tikhonov method, the relative error of the inverse is epsilon / (w^2 + epsilon),
+// and the masked samples are those whose relative error is at most tolerance,
+// w^2 >= epsilon * (1 - tolerance) / tolerance. tolerance is not used by the
+// floor method. nil is returned if the Tikhonov method is used with a tolerance
+// that is not positive.
+func InverseWindowRegularized(windowType WindowType, input VectorComplex, method InverseWindowMethod, epsilon float64, tolerance float64) (VectorComplex, []bool) {
+	w := MakeWindow(windowType, len(input))
+	if w == nil || (method == InverseWindowMethodTikhonov && tolerance <= 0.0) {
+		return nil, nil
+	}
+
+	viw := input.Copy()
+	mask := make([]bool, len(viw))
+	for i := 0; i < len(viw); i++ {
+		switch method {
+		case InverseWindowMethodFloor:
+			d := math.Max(math.Abs(w[i]), epsilon)
+			viw[i] /= complex(math.Copysign(d, w[i]), 0.0)
+			mask[i] = math.Abs(w[i]) >= epsilon
+		case InverseWindowMethodTikhonov:
+			w2 := w[i] * w[i]
+			viw[i] *= complex(w[i]/(w2+epsilon), 0.0)
+			mask[i] = w2*tolerance >= epsilon*(1.0-tolerance)
+		default:
+			return nil, nil
+		}
+	}
+	return viw, mask
+}
+
 // Hann performs Hann windowing on the input vector.
 func Hann(input VectorComplex) VectorComplex {
 	vh := input.Copy()
@@ -120,3 +186,45 @@ func InverseNuttal(input VectorComplex) VectorComplex {
 	}
 	return vih
 }
+
+// Rectangular performs rectangular windowing on the input vector.
+func Rectangular(input VectorComplex) VectorComplex {
+	return input.Copy()
+}
+
+// InverseRectangular performs inverse rectangular windowing on the input vector.
+func InverseRectangular(input VectorComplex) VectorComplex {
+	return input.Copy()
+}
+
+// MakeKaiserWindow creates and returns a Kaiser window of the given length with
+// shape parameter beta.
+func MakeKaiserWindow(length int, beta float64) Vector {
+	w := MakeVector(1.0, length)
+	if length < 2 {
+		return w
+	}
+
+	d := BesselI0(beta)
+	for n := range w {
+		r := 2.0*float64(n)/float64(length-1) - 1.0
+		w[n] = BesselI0(beta*math.Sqrt(1.0-r*r)) / d
+	}
+	return w
+}
+
+// BesselI0 returns the zeroth-order modified Bessel function of the first kind
+// evaluated at x.
+func BesselI0(x float64) float64 {
+	s := 1.0
+	t := 1.0
+	q := x * x / 4.0
+	for k := 1; k < 500; k++ {
+		t *= q / float64(k*k)
+		s += t
+		if t < 1e-17*s {
+			break
+		}
+	}
+	return s
+}
@@ -0,0 +1,82 @@
+package gdsp
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestInverseWindow(t *testing.T) {
+	v := MakeVectorComplex(1.0, 8)
+	iw := InverseWindow(WindowTypeHamming, Window(WindowTypeHamming, v))
+
+	if !iw.IsCloseToVectorC(v, 0.00001) {
+		t.Errorf("%v should be %v.", iw, v)
+	}
+}
+
+func TestInverseWindowRegularized(t *testing.T) {
+	v := MakeVectorComplex(complex(1.0, -1.0), 16)
+	windowTypes := []WindowType{WindowTypeHann, WindowTypeHamming, WindowTypeNuttal}
+	methods := []InverseWindowMethod{InverseWindowMethodFloor, InverseWindowMethodTikhonov}
+
+	for _, windowType := range windowTypes {
+		for _, method := range methods {
+			iw, mask := InverseWindowRegularized(windowType, Window(windowType, v), method, 1e-9, 0.0001)
+			for i, c := range iw {
+				if cmplx.IsNaN(c) || cmplx.IsInf(c) {
+					t.Errorf("Window %d, method %d: element %d is not finite.", windowType, method, i)
+				}
+				if mask[i] && !IsCloseC(c, v[i], 0.0001) {
+					t.Errorf("Window %d, method %d: %v at %d should be %v.", windowType, method, c, i, v[i])
+				}
+			}
+		}
+	}
+}
+
+func TestInverseWindowRegularizedMask(t *testing.T) {
+	v := MakeVectorComplex(1.0, 9)
+	_, mask := InverseWindowRegularized(WindowTypeHann, Hann(v), InverseWindowMethodFloor, 0.01, 0.0)
+
+	if mask[0] || mask[len(mask)-1] {
+		t.Error("Hann window edges should be masked.")
+	}
+
+	if !mask[4] {
+		t.Error("Hann window center should not be masked.")
+	}
+
+	w := MakeWindow(WindowTypeHann, 9)
+	if math.Abs(w[4]-1.0) > 0.000001 {
+		t.Errorf("%f should be 1.0.", w[4])
+	}
+}
+
+func TestInverseWindowRegularizedTikhonovBoundary(t *testing.T) {
+	v := MakeVectorComplex(1.0, 33)
+	w := MakeWindow(WindowTypeHann, len(v))
+	tolerance := 0.01
+
+	// Place the mask boundary exactly at the third sample.
+	epsilon := w[2] * w[2] * tolerance / (1.0 - tolerance)
+	iw, mask := InverseWindowRegularized(WindowTypeHann, Hann(v), InverseWindowMethodTikhonov, epsilon, tolerance)
+
+	for i, c := range iw {
+		relativeError := cmplx.Abs(c-v[i]) / cmplx.Abs(v[i])
+		if mask[i] && relativeError > tolerance+0.000001 {
+			t.Errorf("Masked sample %d has relative error %f.", i, relativeError)
+		}
+		if !mask[i] && relativeError < tolerance-0.000001 {
+			t.Errorf("Unmasked sample %d has relative error %f.", i, relativeError)
+		}
+	}
+
+	if mask[1] || !IsClose(cmplx.Abs(iw[2]-v[2]), tolerance, 0.000001) {
+		t.Errorf("The mask boundary should be at sample 2 with relative error %f.", tolerance)
+	}
+
+	if iw, mask := InverseWindowRegularized(WindowTypeHann, Hann(v), InverseWindowMethodTikhonov, epsilon, 0.0); iw != nil || mask != nil {
+		t.Errorf("A tolerance that is not positive should return nil.")
+	}
+}